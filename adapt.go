@@ -0,0 +1,92 @@
+// Copyright 2020 Brian E. Holland. All rights reserved.
+// The use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package intlist
+
+// NewSliceIterator sets the state for iterating through a copy of values, so
+// an already materialized list can be used wherever an Iter is wanted. Runs of
+// consecutive values are held as sequences, so Progress knows the total.
+//
+//   NewSliceIterator([]int{4, 5, 6, 1}) -> [4 5 6 1]
+func NewSliceIterator(values []int) *Iterator {
+	seqs := make([]seq, len(values))
+	for i, v := range values {
+		seqs[i] = seq{next: v, last: v}
+	}
+	return &Iterator{seqs: minifySeqs(seqs)}
+}
+
+// MapValues validates the specification and sets the state for iterating
+// through f applied to each value of its expansion.
+//
+// The values are mapped as they are retrieved, so nothing is materialized.
+// The "f" parameter must not be nil.
+//
+//   MapValues("1...3,10", func(v int) int { return v + 100 }) ->
+//       [101 102 103 110]
+//
+// Potential errors set in state during creation of an Iterator:
+//
+//   strconv.ErrSyntax - Error parsing integer or sequence notation
+//   strconv.ErrRange - Integer out of range
+func MapValues(spec string, f func(int) int) *Iterator {
+	seqs, err := parseSeqs("MapValues", spec)
+	if err != nil {
+		return &Iterator{err: err}
+	}
+	src := &Iterator{seqs: seqs}
+	return &Iterator{
+		gen: func() (int, bool) {
+			val, err := src.Next()
+			if err == ErrDone {
+				return 0, false
+			}
+			return f(val), true
+		},
+	}
+}
+
+// Interleave validates the specifications and sets the state for iterating
+// through their values round-robin, taking one value from each in turn. A
+// specification that runs out is skipped from then on. This schedules work
+// fairly across several pools of IDs without materializing any of them.
+//
+//   Interleave("1...3", "10,20", "100...105") ->
+//       [1 10 100 2 20 101 3 102 103 104 105]
+//
+// Potential errors set in state during creation of an Iterator:
+//
+//   strconv.ErrSyntax - Error parsing integer or sequence notation
+//   strconv.ErrRange - Integer out of range
+func Interleave(specs ...string) *Iterator {
+	srcs := make([]*Iterator, 0, len(specs)) // Sources not yet run out
+	for _, spec := range specs {
+		seqs, err := parseSeqs("Interleave", spec)
+		if err != nil {
+			return &Iterator{err: err}
+		}
+		if len(seqs) > 0 {
+			srcs = append(srcs, &Iterator{seqs: seqs})
+		}
+	}
+	turn := 0 // Index in srcs of the next source
+	return &Iterator{
+		gen: func() (int, bool) {
+			if len(srcs) == 0 {
+				return 0, false
+			}
+			src := srcs[turn]
+			val, _ := src.Next() // Sources in srcs have values left.
+			if len(src.seqs) == 0 {
+				srcs = append(srcs[:turn], srcs[turn+1:]...)
+			} else {
+				turn++
+			}
+			if turn >= len(srcs) {
+				turn = 0
+			}
+			return val, true
+		},
+	}
+}
@@ -0,0 +1,110 @@
+// Copyright 2020 Brian E. Holland. All rights reserved.
+// The use of this source code is governed by an MIT license
+// that can be found in the LICENSE file.
+
+package intlist_test
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/brianholland99/intlist"
+	"github.com/google/go-cmp/cmp"
+)
+
+// collect returns the remaining values of a valid Iter.
+func collect(it intlist.Iter) []int {
+	out := []int{}
+	for {
+		val, err := it.Next()
+		if err == intlist.ErrDone {
+			return out
+		}
+		out = append(out, val)
+	}
+}
+
+// Iterators of every kind are interchangeable.
+var _ intlist.Iter = intlist.NewSliceIterator(nil)
+
+func TestNewSliceIterator(t *testing.T) {
+	for _, test := range parseTests {
+		if test.err != nil {
+			continue
+		}
+		var it intlist.Iter = intlist.NewSliceIterator(test.out)
+		if out := collect(it); !cmp.Equal(out, test.out) || !it.Done() || it.Err() != nil {
+			t.Errorf("NewSliceIterator(%v) = %v, Done() = %v, Err() = %v -- wanted %v, true, nil",
+				test.out, out, it.Done(), it.Err(), test.out)
+		}
+	}
+	values := []int{4, 5, 6, 1}
+	it := intlist.NewSliceIterator(values)
+	values[0] = 99 // Iterator holds a copy.
+	if _, total := it.Progress(); total != 4 {
+		t.Errorf("NewSliceIterator(%v).Progress() total = %d -- wanted 4", values, total)
+	}
+	if out, want := collect(it), []int{4, 5, 6, 1}; !cmp.Equal(out, want) {
+		t.Errorf("NewSliceIterator after changing slice = %v -- wanted %v", out, want)
+	}
+}
+
+var mapValuesTests = []parseTest{
+	// Good cases
+	{"1...3,10", []int{2, 4, 6, 20}, nil}, // Ints and Seqs
+	{"", []int{}, nil},                    // Empty list
+	// Error cases
+	{"2...5.4", nil, strconv.ErrSyntax}, // Seq. end - non-integer
+}
+
+func TestMapValues(t *testing.T) {
+	double := func(v int) int { return 2 * v }
+	for _, test := range mapValuesTests {
+		it := intlist.MapValues(test.in, double)
+		if !errors.Is(it.Err(), test.err) {
+			t.Errorf("MapValues(%q) error = (%v) -- wanted (%v)",
+				test.in, it.Err(), test.err)
+			continue
+		}
+		if test.err != nil {
+			continue
+		}
+		if out := collect(it); !cmp.Equal(out, test.out) {
+			t.Errorf("MapValues(%q) = %v -- wanted %v", test.in, out, test.out)
+		}
+	}
+}
+
+type interleaveTest struct {
+	in  []string
+	out []int
+	err error
+}
+
+var interleaveTests = []interleaveTest{
+	// Good cases
+	{[]string{"1...3", "10,20", "100...105"},
+		[]int{1, 10, 100, 2, 20, 101, 3, 102, 103, 104, 105}, nil}, // Uneven
+	{[]string{"", "5...7", ""}, []int{5, 6, 7}, nil}, // Empty lists
+	{[]string{"1", "2"}, []int{1, 2}, nil},
+	{nil, []int{}, nil}, // No specifications
+	// Error cases
+	{[]string{"1", "2..3"}, nil, strconv.ErrSyntax},
+}
+
+func TestInterleave(t *testing.T) {
+	for _, test := range interleaveTests {
+		it := intlist.Interleave(test.in...)
+		if !errors.Is(it.Err(), test.err) {
+			t.Errorf("Interleave(%q) error = (%v) -- wanted (%v)", test.in, it.Err(), test.err)
+			continue
+		}
+		if test.err != nil {
+			continue
+		}
+		if out := collect(it); !cmp.Equal(out, test.out) {
+			t.Errorf("Interleave(%q) = %v -- wanted %v", test.in, out, test.out)
+		}
+	}
+}
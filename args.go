@@ -0,0 +1,29 @@
+// Copyright 2020 Brian E. Holland. All rights reserved.
+// The use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package intlist
+
+// ParseArgs is Parse for command-line arguments, where each argument holds
+// one or more items, so "prog 1...5 9 20...25" works without quoting commas.
+// The values are those of each argument in turn. An empty argument adds no
+// values.
+//
+//   ParseArgs([]string{"1...3", "9,7", "20...18"}) ->
+//       [1 2 3 9 7 20 19 18], nil
+//
+// Potential errors returned:
+//
+//   strconv.ErrSyntax - Error parsing integer or sequence notation
+//   strconv.ErrRange - Integer out of range
+func ParseArgs(args []string) ([]int, error) {
+	var seqs []seq
+	for _, arg := range args {
+		argSeqs, err := parseSpec("ParseArgs", arg, &config{})
+		if err != nil {
+			return nil, err
+		}
+		seqs = append(seqs, argSeqs...)
+	}
+	return (&Iterator{seqs: seqs}).rest(), nil
+}
@@ -0,0 +1,40 @@
+// Copyright 2020 Brian E. Holland. All rights reserved.
+// The use of this source code is governed by an MIT license
+// that can be found in the LICENSE file.
+
+package intlist_test
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/brianholland99/intlist"
+	"github.com/google/go-cmp/cmp"
+)
+
+var parseArgsTests = []struct {
+	in  []string
+	out []int
+	err error
+}{
+	// Good cases
+	{[]string{"1...3", "9,7", "20...18"}, []int{1, 2, 3, 9, 7, 20, 19, 18}, nil},
+	{[]string{"5", "", "6"}, []int{5, 6}, nil}, // Empty argument
+	{[]string{"4,4"}, []int{4, 4}, nil},        // Repeats kept
+	{nil, []int{}, nil},                        // No arguments
+	// Error cases
+	{[]string{"1", "2,"}, nil, strconv.ErrSyntax}, // Trailing comma
+	{[]string{"1..5"}, nil, strconv.ErrSyntax},
+	{[]string{"99999999999999999999"}, nil, strconv.ErrRange},
+}
+
+func TestParseArgs(t *testing.T) {
+	for _, test := range parseArgsTests {
+		out, err := intlist.ParseArgs(test.in)
+		if !cmp.Equal(out, test.out) || !errors.Is(err, test.err) {
+			t.Errorf("ParseArgs(%q) = (%v), (%v) -- wanted (%v), (%v)",
+				test.in, out, err, test.out, test.err)
+		}
+	}
+}
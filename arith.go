@@ -0,0 +1,126 @@
+// Copyright 2020 Brian E. Holland. All rights reserved.
+// The use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package intlist
+
+import (
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// arithmeticChars are the characters of a constant expression.
+const arithmeticChars = "0123456789+-*/%() \t"
+
+// parseArithmetic parses tok as a constant expression of integers using +, -,
+// *, / and % with the usual precedence and parentheses (E.g., "3*1024-1"), so
+// endpoints derived from block sizes need no preprocessing. Division truncates
+// toward zero as in Go. Blanks are allowed between the parts, but not around
+// the whole. It reports false if tok holds anything else. Division
+// by zero is a syntax error. Intermediate results may be of any size, while
+// the result must fit in an int.
+func parseArithmetic(tok string) (int, bool, error) {
+	if strings.Trim(tok, arithmeticChars) != "" || tok != strings.TrimSpace(tok) ||
+		!strings.ContainsAny(tok, "0123456789") {
+		return 0, false, nil
+	}
+	p := &exprParser{rest: tok}
+	x, ok := p.expr()
+	if !ok || strings.TrimSpace(p.rest) != "" {
+		return 0, true, strconv.ErrSyntax
+	}
+	switch {
+	case x.IsInt64() && x.Int64() >= minInt && x.Int64() <= maxInt:
+		return int(x.Int64()), true, nil
+	case x.Sign() > 0:
+		return maxInt, true, strconv.ErrRange
+	}
+	return minInt, true, strconv.ErrRange
+}
+
+// exprParser is the state of parsing a constant expression.
+type exprParser struct {
+	rest string // Text not yet parsed
+}
+
+// expr parses a sum of terms. It reports false if the text is malformed.
+func (p *exprParser) expr() (*big.Int, bool) {
+	x, ok := p.term()
+	for ok {
+		var op byte
+		if op = p.operator("+-"); op == 0 {
+			break
+		}
+		var y *big.Int
+		if y, ok = p.term(); ok && op == '+' {
+			x.Add(x, y)
+		} else if ok {
+			x.Sub(x, y)
+		}
+	}
+	return x, ok
+}
+
+// term parses a product of factors. It reports false if the text is
+// malformed.
+func (p *exprParser) term() (*big.Int, bool) {
+	x, ok := p.factor()
+	for ok {
+		var op byte
+		if op = p.operator("*/%"); op == 0 {
+			break
+		}
+		var y *big.Int
+		if y, ok = p.factor(); !ok {
+			break
+		}
+		switch {
+		case op == '*':
+			x.Mul(x, y)
+		case y.Sign() == 0:
+			ok = false // Division by zero
+		case op == '/':
+			x.Quo(x, y)
+		default:
+			x.Rem(x, y)
+		}
+	}
+	return x, ok
+}
+
+// factor parses an integer, a signed factor or a parenthesized expression. It
+// reports false if the text is malformed.
+func (p *exprParser) factor() (*big.Int, bool) {
+	if op := p.operator("+-("); op != 0 {
+		if op == '(' {
+			x, ok := p.expr()
+			return x, ok && p.operator(")") != 0
+		}
+		x, ok := p.factor()
+		if ok && op == '-' {
+			x.Neg(x)
+		}
+		return x, ok
+	}
+	p.rest = strings.TrimLeft(p.rest, " \t")
+	n := 0
+	for n < len(p.rest) && p.rest[n] >= '0' && p.rest[n] <= '9' {
+		n++
+	}
+	x, ok := new(big.Int).SetString(p.rest[:n], 10)
+	p.rest = p.rest[n:]
+	return x, ok
+}
+
+// operator consumes the next character, after any blanks, if it is one of
+// ops and returns it. It returns 0 otherwise.
+func (p *exprParser) operator(ops string) byte {
+	p.rest = strings.TrimLeft(p.rest, " \t")
+	if p.rest != "" && strings.IndexByte(ops, p.rest[0]) >= 0 {
+		op := p.rest[0]
+		p.rest = p.rest[1:]
+		return op
+	}
+	return 0
+}
@@ -0,0 +1,50 @@
+// Copyright 2020 Brian E. Holland. All rights reserved.
+// The use of this source code is governed by an MIT license
+// that can be found in the LICENSE file.
+
+package intlist_test
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/brianholland99/intlist"
+	"github.com/google/go-cmp/cmp"
+)
+
+var arithmeticTests = []parseTest{
+	// Good cases
+	{"2*2...2*3-1", []int{4, 5}, nil},
+	{"1+2*3,(1+2)*3,-(4),--4", []int{7, 9, -4, 4}, nil}, // Precedence and signs
+	{"7/2,-7/2,7%3,-7%3", []int{3, -3, 1, -1}, nil},     // Truncating as in Go
+	{"2 * 8 - 1", []int{15}, nil},                       // Blanks between parts
+	{"99999999999999999999-99999999999999999998", []int{1}, nil},
+	// Error cases
+	{"1/0", nil, strconv.ErrSyntax},
+	{"1-", nil, strconv.ErrSyntax},
+	{"(1+2", nil, strconv.ErrSyntax},
+	{"1+2)", nil, strconv.ErrSyntax},
+	{"2 3", nil, strconv.ErrSyntax},
+	{" 1+1", nil, strconv.ErrSyntax}, // Blank around the whole
+	{"()", nil, strconv.ErrSyntax},
+	{"4294967296*4294967296", nil, strconv.ErrRange},
+	{"-4294967296*4294967296*2", nil, strconv.ErrRange},
+}
+
+func TestArithmetic(t *testing.T) {
+	for _, test := range arithmeticTests {
+		out, err := intlist.ParseWithOptions(test.in,
+			intlist.WithFeatures(intlist.FeatureArithmetic))
+		if !cmp.Equal(out, test.out) || !errors.Is(err, test.err) {
+			t.Errorf("ParseWithOptions(%q, WithFeatures(FeatureArithmetic)) = (%v), (%v) -- "+
+				"wanted (%v), (%v)", test.in, out, err, test.out, test.err)
+		}
+	}
+	// Not enabled, a typo for a sequence is still suggested as one.
+	var parseErr *intlist.ParseError
+	_, err := intlist.Rank("1-5", 3)
+	if !errors.As(err, &parseErr) || parseErr.Suggestion != "1...5" {
+		t.Errorf("Rank(%q) error = %v -- wanted suggestion %q", "1-5", err, "1...5")
+	}
+}
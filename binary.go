@@ -0,0 +1,86 @@
+// Copyright 2020 Brian E. Holland. All rights reserved.
+// The use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package intlist
+
+import (
+	"bufio"
+	"encoding/binary"
+	"io"
+	"strconv"
+)
+
+// validWidth reports whether width is a number of bytes WriteTo can write.
+func validWidth(width int) bool {
+	return width == 1 || width == 2 || width == 4 || width == 8
+}
+
+// WriteTo writes the remaining values of i to w as fixed-width binary
+// integers in two's complement, so values can go to numeric file formats and
+// sockets without a text round-trip. It implements io.WriterTo. The format is
+// 8 bytes in big-endian order unless changed by WithBinaryFormat. It returns
+// the number of bytes written and the error set in the state of i if it is
+// invalid.
+//
+//   NewIterator("1,258").WriteTo(w) -> 16, nil with
+//       00 00 00 00 00 00 00 01 00 00 00 00 00 00 01 02 written to w
+//
+// Potential errors returned:
+//
+//   strconv.ErrRange - Value does not fit in the width
+//   Errors creating i or writing to w
+func (i *Iterator) WriteTo(w io.Writer) (int64, error) {
+	if i.err != nil {
+		return 0, i.err
+	}
+	width, order := i.binWidth, i.binOrder
+	if width == 0 {
+		width = 8
+	}
+	if order == nil {
+		order = binary.BigEndian
+	}
+	bits := uint(8 * width)
+	cw := &countWriter{w: w}
+	bw := bufio.NewWriter(cw)
+	buf := make([]byte, 8)
+	for !i.done {
+		val, err := i.Next()
+		if err == ErrDone {
+			break
+		}
+		// Accept values fitting the width as either signed or unsigned.
+		if v := int64(val); bits < 64 && (v < -1<<(bits-1) || v > 1<<bits-1) {
+			bw.Flush()
+			return cw.n, &strconv.NumError{Func: "WriteTo", Num: strconv.Itoa(val), Err: strconv.ErrRange}
+		}
+		switch width {
+		case 1:
+			buf[0] = byte(val)
+		case 2:
+			order.PutUint16(buf, uint16(val))
+		case 4:
+			order.PutUint32(buf, uint32(val))
+		default:
+			order.PutUint64(buf, uint64(val))
+		}
+		if _, err := bw.Write(buf[:width]); err != nil {
+			return cw.n, err
+		}
+	}
+	err := bw.Flush()
+	return cw.n, err
+}
+
+// countWriter is an io.Writer counting the bytes written to w.
+type countWriter struct {
+	w io.Writer // Destination
+	n int64     // Bytes written
+}
+
+func (cw *countWriter) Write(p []byte) (int, error) {
+	n, err := cw.w.Write(p)
+	cw.n += int64(n)
+	return n, err
+}
@@ -0,0 +1,53 @@
+// Copyright 2020 Brian E. Holland. All rights reserved.
+// The use of this source code is governed by an MIT license
+// that can be found in the LICENSE file.
+
+package intlist_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+	"strconv"
+	"testing"
+
+	"github.com/brianholland99/intlist"
+)
+
+var _ io.WriterTo = (*intlist.Iterator)(nil)
+
+var writeToTests = []struct {
+	in   string
+	opts []intlist.Option
+	out  []byte
+	err  error
+}{
+	// Good cases
+	{"1,258", nil, []byte{0, 0, 0, 0, 0, 0, 0, 1, 0, 0, 0, 0, 0, 0, 1, 2}, nil},
+	{"1,258", []intlist.Option{intlist.WithBinaryFormat(2, binary.BigEndian)},
+		[]byte{0, 1, 1, 2}, nil},
+	{"1,258", []intlist.Option{intlist.WithBinaryFormat(4, binary.LittleEndian)},
+		[]byte{1, 0, 0, 0, 2, 1, 0, 0}, nil},
+	{"-1,255,-128", []intlist.Option{intlist.WithBinaryFormat(1, nil)},
+		[]byte{0xff, 0xff, 0x80}, nil}, // Signed or unsigned
+	{"", nil, []byte{}, nil},
+	// Error cases
+	{"1,256,2", []intlist.Option{intlist.WithBinaryFormat(1, nil)},
+		[]byte{1}, strconv.ErrRange}, // Values before the error are written
+	{"-129", []intlist.Option{intlist.WithBinaryFormat(1, nil)}, []byte{}, strconv.ErrRange},
+	{"1", []intlist.Option{intlist.WithBinaryFormat(3, nil)}, []byte{}, intlist.ErrInvalidArg},
+	{"1..2", nil, []byte{}, strconv.ErrSyntax},
+}
+
+func TestWriteTo(t *testing.T) {
+	for _, test := range writeToTests {
+		var b bytes.Buffer
+		it := intlist.NewIteratorWithOptions(test.in, test.opts...)
+		n, err := it.WriteTo(&b)
+		if !bytes.Equal(b.Bytes(), test.out) || n != int64(len(test.out)) || !errors.Is(err, test.err) {
+			t.Errorf("WriteTo(%q) wrote % x, (%d), (%v) -- wanted % x, (%d), (%v)",
+				test.in, b.Bytes(), n, err, test.out, len(test.out), test.err)
+		}
+	}
+}
@@ -0,0 +1,209 @@
+// Copyright 2020 Brian E. Holland. All rights reserved.
+// The use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package intlist
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// ToBrace returns a bash brace expansion producing the values of the passed
+// specification in the same order, so it can be pasted into a shell.
+//
+// Sequences become ranges (E.g., "{1..10}") inside a list of the items. A
+// single integer is written as is, since bash leaves "{5}" unexpanded.
+//
+//   ToBrace("1...3,7,10...8") -> "{{1..3},7,{10..8}}", nil
+//   ToBrace("1...10") -> "{1..10}", nil
+//
+// Potential errors returned:
+//
+//   strconv.ErrSyntax - Error parsing integer or sequence notation
+//   strconv.ErrRange - Integer out of range
+func ToBrace(spec string) (string, error) {
+	seqs, err := parseSeqs("ToBrace", spec)
+	if err != nil {
+		return "", err
+	}
+	if len(seqs) == 1 {
+		return braceSeq(seqs[0]), nil
+	}
+	var b strings.Builder
+	for i, s := range seqs {
+		if i == 0 {
+			b.WriteByte('{')
+		} else {
+			b.WriteByte(',')
+		}
+		b.WriteString(braceSeq(s))
+	}
+	if len(seqs) > 0 {
+		b.WriteByte('}')
+	}
+	return b.String(), nil
+}
+
+// braceSeq returns the bash brace expansion for s.
+func braceSeq(s seq) string {
+	if s.next == s.last && s.reps > 0 {
+		v := strconv.Itoa(s.next)
+		return "{" + v + strings.Repeat(","+v, s.reps) + "}"
+	}
+	if s.next == s.last {
+		return strconv.Itoa(s.next)
+	}
+	result := "{" + strconv.Itoa(s.next) + ".." + strconv.Itoa(s.last)
+	if stride := s.stride(); stride != 1 {
+		result += ".." + strconv.FormatUint(stride, 10)
+	}
+	return result + "}"
+}
+
+// FromBrace returns a specification expanding to the values of the passed
+// bash brace expansion of integers in the same order, so shell snippets can
+// be imported.
+//
+// Ranges with or without an increment (E.g., "{1..10..2}"), lists (E.g.,
+// "{1,5,9}"), nested lists and plain integers are accepted, as are several of
+// them separated by whitespace. As in bash, the sign of an increment is
+// ignored and an increment of 0 is taken as 1. Leading zeros are accepted
+// but the padding they produce in bash is not kept.
+//
+//   FromBrace("{{1..3},7} {10..8}") -> "1...3,7,10...8", nil
+//   FromBrace("{1..7..3}") -> "1...7:3", nil
+//
+// Potential errors returned:
+//
+//   strconv.ErrSyntax - Not a brace expansion of integers
+//   strconv.ErrRange - Integer out of range
+func FromBrace(brace string) (string, error) {
+	p := &braceParser{fn: "FromBrace", in: brace}
+	var seqs []seq
+	for _, word := range strings.Fields(brace) {
+		p.rest = word
+		if err := p.term(&seqs); err != nil {
+			return "", err
+		}
+		if p.rest != "" {
+			return "", p.syntaxError()
+		}
+	}
+	return formatSeqs(seqs), nil
+}
+
+// braceParser is the state for parsing a bash brace expansion.
+type braceParser struct {
+	fn   string // Name of the calling function used in any error returned
+	in   string // Whole brace expansion for errors
+	rest string // Text not yet parsed
+}
+
+// syntaxError returns the error for a malformed brace expansion.
+func (p *braceParser) syntaxError() error {
+	return &strconv.NumError{Func: p.fn, Num: p.in, Err: strconv.ErrSyntax}
+}
+
+// term parses an integer, range or list, appending its sequences to seqs.
+func (p *braceParser) term(seqs *[]seq) error {
+	if !strings.HasPrefix(p.rest, "{") {
+		v, err := p.integer()
+		if err != nil {
+			return err
+		}
+		*seqs = append(*seqs, seq{next: v, last: v})
+		return nil
+	}
+	p.rest = p.rest[1:]
+	if !strings.HasPrefix(p.rest, "{") {
+		// Either a range or a list starting with an integer
+		first, err := p.integer()
+		if err != nil {
+			return err
+		}
+		if strings.HasPrefix(p.rest, "..") {
+			return p.braceRange(seqs, first)
+		}
+		*seqs = append(*seqs, seq{next: first, last: first})
+	} else if err := p.term(seqs); err != nil {
+		return err
+	}
+	if !strings.HasPrefix(p.rest, ",") {
+		return p.syntaxError() // Bash leaves a list of one unexpanded.
+	}
+	for strings.HasPrefix(p.rest, ",") {
+		p.rest = p.rest[1:]
+		if err := p.term(seqs); err != nil {
+			return err
+		}
+	}
+	return p.expect("}")
+}
+
+// braceRange parses the rest of a range starting with first, appending its
+// sequence to seqs.
+func (p *braceParser) braceRange(seqs *[]seq, first int) error {
+	p.rest = p.rest[2:]
+	last, err := p.integer()
+	if err != nil {
+		return err
+	}
+	var stride uint64 = 1
+	if strings.HasPrefix(p.rest, "..") {
+		p.rest = p.rest[2:]
+		incr, err := p.integer()
+		if err != nil {
+			return err
+		}
+		if incr < 0 {
+			stride = -uint64(incr)
+		} else if incr > 0 {
+			stride = uint64(incr)
+		}
+	}
+	s := seq{next: first, last: first}
+	if first < last {
+		s.step = int(stride)
+		s.last = int(uint64(first) + (uint64(last)-uint64(first))/stride*stride)
+	} else if first > last {
+		s.step = -int(stride)
+		s.last = int(uint64(first) - (uint64(first)-uint64(last))/stride*stride)
+	}
+	if s.next == s.last {
+		s.step = 0
+	}
+	*seqs = append(*seqs, s)
+	return p.expect("}")
+}
+
+// integer parses a decimal integer with an optional sign.
+func (p *braceParser) integer() (int, error) {
+	n := 0
+	if strings.HasPrefix(p.rest, "-") || strings.HasPrefix(p.rest, "+") {
+		n = 1
+	}
+	for n < len(p.rest) && p.rest[n] >= '0' && p.rest[n] <= '9' {
+		n++
+	}
+	v, err := strconv.Atoi(p.rest[:n])
+	if err != nil {
+		if errors.Is(err, strconv.ErrRange) {
+			return 0, &strconv.NumError{Func: p.fn, Num: p.rest[:n], Err: strconv.ErrRange}
+		}
+		return 0, p.syntaxError()
+	}
+	p.rest = p.rest[n:]
+	return v, nil
+}
+
+// expect consumes tok or returns a syntax error if the text does not start
+// with it.
+func (p *braceParser) expect(tok string) error {
+	if !strings.HasPrefix(p.rest, tok) {
+		return p.syntaxError()
+	}
+	p.rest = p.rest[len(tok):]
+	return nil
+}
@@ -0,0 +1,69 @@
+// Copyright 2020 Brian E. Holland. All rights reserved.
+// The use of this source code is governed by an MIT license
+// that can be found in the LICENSE file.
+
+package intlist_test
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/brianholland99/intlist"
+)
+
+var toBraceTests = []transformTest{
+	// Good cases
+	{"1...3,7,10...8", "{{1..3},7,{10..8}}", nil}, // Mixed
+	{"1...10", "{1..10}", nil},                    // One sequence
+	{"7x3", "{7,7,7}", nil},                       // Repetition
+	{"5", "5", nil},                               // One integer
+	{"5,6", "{5,6}", nil},                         // Integers
+	{"-2...-4", "{-2..-4}", nil},                  // Negatives
+	{"", "", nil},                                 // Empty list
+	// Error cases
+	{"1..3", "", strconv.ErrSyntax},
+}
+
+var fromBraceTests = []transformTest{
+	// Good cases
+	{"{{1..3},7} {10..8}", "1...3,7,10...8", nil}, // Nested and several words
+	{"{1..7..3}", "1...7:3", nil},                 // Increment
+	{"{1..8..3}", "1...7:3", nil},                 // Increment past end
+	{"{10..1..-4}", "10...2:4", nil},              // Sign of increment ignored
+	{"{1..3..0}", "1...3", nil},                   // Increment of 0
+	{"{5..5}", "5", nil},                          // Range of one
+	{"{1,{4..6},9}", "1,4...6,9", nil},            // Range inside a list
+	{"-3 +4", "-3,4", nil},                        // Plain integers
+	{"{01..03}", "1...3", nil},                    // Padding dropped
+	{"", "", nil},                                 // Nothing
+	// Error cases
+	{"{5}", "", strconv.ErrSyntax},     // Not expanded by bash
+	{"{1..3", "", strconv.ErrSyntax},   // Unclosed
+	{"{1..3}}", "", strconv.ErrSyntax}, // Extra brace
+	{"{a..c}", "", strconv.ErrSyntax},  // Letters
+	{"{1...3}", "", strconv.ErrSyntax}, // Intlist ellipsis
+	{"x{1,2}", "", strconv.ErrSyntax},  // Preamble
+	{"{1,}", "", strconv.ErrSyntax},    // Empty element
+	{"{1..99999999999999999999}", "", strconv.ErrRange},
+}
+
+func TestToBrace(t *testing.T) {
+	checkTransform(t, "ToBrace", intlist.ToBrace, toBraceTests)
+}
+
+func TestFromBrace(t *testing.T) {
+	checkTransform(t, "FromBrace", intlist.FromBrace, fromBraceTests)
+}
+
+// Converting to brace expansion and back gives the same specification.
+func TestBraceRoundTrip(t *testing.T) {
+	for _, spec := range []string{"1...3,7,10...8", "1...10", "5", "5,6", ""} {
+		brace, err := intlist.ToBrace(spec)
+		if err != nil {
+			t.Fatalf("ToBrace(%q) error = %v", spec, err)
+		}
+		if back, err := intlist.FromBrace(brace); back != spec || err != nil {
+			t.Errorf("FromBrace(%q) = (%q), (%v) -- wanted (%q), (nil)", brace, back, err, spec)
+		}
+	}
+}
@@ -0,0 +1,54 @@
+// Copyright 2020 Brian E. Holland. All rights reserved.
+// The use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package intlist
+
+// ResolveColumns returns the indices (origin 0) of the columns selected by the
+// passed specification for a table with headerLen columns, like cut(1).
+//
+// Columns in the specification are numbered from 1. A negative number counts
+// back from the last column, so -1 is the last column and -2 the one before
+// it. A sequence may leave out its start or end to mean the first or last
+// column (E.g., "3..." or "...-2"). The order of the specification is kept
+// along with any repeats, so columns can be reordered.
+//
+//   ResolveColumns("1,3...", 5) -> [0 2 3 4], nil
+//   ResolveColumns("-1...-2,1", 5) -> [4 3 0], nil
+//
+// Potential errors returned:
+//
+//   strconv.ErrSyntax - Error parsing integer or sequence notation
+//   strconv.ErrRange - Integer out of range
+//   ErrOutOfBounds - Column 0 or outside of the table
+func ResolveColumns(spec string, headerLen int) ([]int, error) {
+	seqs, err := parseSpec("ResolveColumns", spec, columnConfig(headerLen))
+	if err != nil {
+		return nil, err
+	}
+	result := (&Iterator{seqs: seqs}).rest()
+	for i := range result {
+		result[i]-- // Change to origin 0.
+	}
+	return result, nil
+}
+
+// columnConfig returns the configuration for parsing a selection of columns
+// numbered from 1 in a table of n columns.
+func columnConfig(n int) *config {
+	return &config{
+		features: extensions,
+		bounded:  true,
+		lo:       1,
+		hi:       n,
+		open:     true,
+		openLo:   1,
+		openHi:   n,
+		resolve: func(v int) int {
+			if v < 0 {
+				return n + 1 + v
+			}
+			return v
+		},
+	}
+}
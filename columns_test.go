@@ -0,0 +1,45 @@
+// Copyright 2020 Brian E. Holland. All rights reserved.
+// The use of this source code is governed by an MIT license
+// that can be found in the LICENSE file.
+
+package intlist_test
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/brianholland99/intlist"
+	"github.com/google/go-cmp/cmp"
+)
+
+type resolveTest struct {
+	in  string
+	n   int
+	out []int
+	err error
+}
+
+var resolveColumnsTests = []resolveTest{
+	// Good cases
+	{"1,3...", 5, []int{0, 2, 3, 4}, nil}, // Open end
+	{"-1...-2,1", 5, []int{4, 3, 0}, nil}, // Negative and reordered
+	{"...2,2", 5, []int{0, 1, 1}, nil},    // Open start and repeat
+	{"...", 3, []int{0, 1, 2}, nil},       // Every column
+	{"", 3, []int{}, nil},                 // No columns
+	// Error cases
+	{"0", 5, nil, intlist.ErrOutOfBounds},     // No column 0
+	{"4...6", 5, nil, intlist.ErrOutOfBounds}, // Past the end
+	{"-6", 5, nil, intlist.ErrOutOfBounds},    // Before the start
+	{"1,,2", 5, nil, strconv.ErrSyntax},       // Empty item
+}
+
+func TestResolveColumns(t *testing.T) {
+	for _, test := range resolveColumnsTests {
+		out, err := intlist.ResolveColumns(test.in, test.n)
+		if !cmp.Equal(out, test.out) || !errors.Is(err, test.err) {
+			t.Errorf("ResolveColumns(%q, %d) = (%v), (%v) -- wanted (%v), (%v)",
+				test.in, test.n, out, err, test.out, test.err)
+		}
+	}
+}
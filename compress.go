@@ -0,0 +1,72 @@
+// Copyright 2020 Brian E. Holland. All rights reserved.
+// The use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package intlist
+
+import (
+	"io"
+	"strings"
+)
+
+// Compressor writes a specification for a stream of integers as they arrive,
+// such as for logging live IDs compactly. Consecutive values continuing a
+// run with a step of 1 or -1 are written as one sequence, keeping the order
+// of the values. Only the run being built is held in memory.
+type Compressor struct {
+	w     io.Writer // Destination
+	cur   seq       // Run being built
+	have  bool      // Whether cur holds a run
+	wrote bool      // Whether an item was written
+	err   error     // First write error
+}
+
+// NewCompressor returns a Compressor writing to w.
+//
+//   c := NewCompressor(w)
+//   for _, v := range []int{1, 2, 3, 7, 6} { c.Write(v) }
+//   c.Flush() -> "1...3,7...6" written to w, nil
+func NewCompressor(w io.Writer) *Compressor {
+	return &Compressor{w: w}
+}
+
+// Write adds v to the stream. Items are written to the destination once a
+// value does not continue the run being built. The first write error is
+// returned by this and every later call.
+func (c *Compressor) Write(v int) error {
+	if c.err != nil {
+		return c.err
+	}
+	single := seq{next: v, last: v}
+	if c.have {
+		if merged, ok := continueRun(c.cur, single); ok {
+			c.cur = merged
+			return nil
+		}
+		c.writeCur()
+	}
+	c.cur, c.have = single, true
+	return c.err
+}
+
+// Flush writes the run being built, if any, so everything written so far is
+// in the destination. More values may be written after. The first write
+// error is returned by this and every later call.
+func (c *Compressor) Flush() error {
+	if c.have && c.err == nil {
+		c.writeCur()
+		c.have = false
+	}
+	return c.err
+}
+
+// writeCur writes the run being built as the next item.
+func (c *Compressor) writeCur() {
+	var b strings.Builder
+	if c.wrote {
+		b.WriteByte(',')
+	}
+	writeSeq(&b, c.cur)
+	_, c.err = io.WriteString(c.w, b.String())
+	c.wrote = true
+}
@@ -0,0 +1,77 @@
+// Copyright 2020 Brian E. Holland. All rights reserved.
+// The use of this source code is governed by an MIT license
+// that can be found in the LICENSE file.
+
+package intlist_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/brianholland99/intlist"
+)
+
+var compressorTests = []struct {
+	in  []int
+	out string
+}{
+	{[]int{1, 2, 3, 7, 6}, "1...3,7...6"},
+	{[]int{5, 5, 4, 3, 9}, "5,5...3,9"},
+	{[]int{1, 3, 2, 1}, "1,3...1"},
+	{[]int{-1, 0, 1}, "-1...1"},
+	{[]int{42}, "42"},
+	{nil, ""},
+}
+
+func TestCompressor(t *testing.T) {
+	for _, test := range compressorTests {
+		var b strings.Builder
+		c := intlist.NewCompressor(&b)
+		for _, v := range test.in {
+			if err := c.Write(v); err != nil {
+				t.Fatalf("Write(%d) error = %v", v, err)
+			}
+		}
+		if err := c.Flush(); err != nil || b.String() != test.out {
+			t.Errorf("Compressor(%v) wrote (%q), (%v) -- wanted (%q), (nil)",
+				test.in, b.String(), err, test.out)
+		}
+	}
+}
+
+// Items are written as soon as they are complete, and Flush leaves the
+// Compressor usable.
+func TestCompressorIncremental(t *testing.T) {
+	var b strings.Builder
+	c := intlist.NewCompressor(&b)
+	for _, v := range []int{1, 2, 3, 10} {
+		c.Write(v)
+	}
+	if b.String() != "1...3" {
+		t.Errorf("Compressor wrote %q before Flush -- wanted %q", b.String(), "1...3")
+	}
+	c.Flush()
+	c.Write(11)
+	c.Flush()
+	if want := "1...3,10,11"; b.String() != want {
+		t.Errorf("Compressor wrote %q -- wanted %q", b.String(), want)
+	}
+}
+
+type failWriter struct{}
+
+var errWrite = errors.New("write failed")
+
+func (failWriter) Write([]byte) (int, error) { return 0, errWrite }
+
+func TestCompressorWriteError(t *testing.T) {
+	c := intlist.NewCompressor(failWriter{})
+	c.Write(1)
+	if err := c.Write(5); err != errWrite {
+		t.Errorf("Write(5) error = %v -- wanted %v", err, errWrite)
+	}
+	if err := c.Flush(); err != errWrite {
+		t.Errorf("Flush() error = %v -- wanted %v", err, errWrite)
+	}
+}
@@ -0,0 +1,95 @@
+// Copyright 2020 Brian E. Holland. All rights reserved.
+// The use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package intlist
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+)
+
+// ResolveCPUs returns the CPU indices selected by the passed specification for
+// pinning work to CPUs the process may run on. On Linux these are the CPUs of
+// its affinity mask, read from /proc/self/status, so a selected CPU outside
+// the mask is an error even if its index is below the highest allowed.
+// Elsewhere, or if the mask can't be read, it is ResolveCPUsFor with the
+// count reported by runtime.NumCPU.
+//
+//   ResolveCPUs("0...3") -> [0 1 2 3], nil (with CPUs 0 through 3 allowed)
+//
+// Potential errors returned are those of ResolveCPUsFor, along with:
+//
+//   ErrNotAllowed - CPU not in the affinity mask
+func ResolveCPUs(spec string) ([]int, error) {
+	const fn = "ResolveCPUs"
+	allowed, ok := affinity()
+	if !ok {
+		return resolveCPUs(fn, spec, runtime.NumCPU(), nil)
+	}
+	spans := normalize(seqSpans(allowed))
+	return resolveCPUs(fn, spec, spans[len(spans)-1].hi+1, spans)
+}
+
+// parseAffinity returns the sequences of the CPUs in the Cpus_allowed_list
+// line of the passed contents of /proc/self/status (E.g., "0-3,8"). It reports
+// false if there is no such line or it can't be parsed.
+func parseAffinity(status string) ([]seq, bool) {
+	for _, line := range strings.Split(status, "\n") {
+		name, list, found := strings.Cut(line, ":")
+		if !found || name != "Cpus_allowed_list" {
+			continue
+		}
+		seqs, err := parseSpec("ResolveCPUs", strings.TrimSpace(list),
+			&config{dialect: DialectPageRange})
+		if err != nil || len(seqs) == 0 {
+			return nil, false
+		}
+		return seqs, true
+	}
+	return nil, false
+}
+
+// ResolveCPUsFor returns the CPU indices selected by the passed specification
+// for a machine with n CPUs numbered from 0.
+//
+// The order of the specification is kept. A CPU may only be selected once.
+//
+//   ResolveCPUsFor("0,2...3", 4) -> [0 2 3], nil
+//   ResolveCPUsFor("4", 4) -> nil, ErrOutOfBounds
+//
+// Potential errors returned:
+//
+//   strconv.ErrSyntax - Error parsing integer or sequence notation
+//   strconv.ErrRange - Integer out of range
+//   ErrOutOfBounds - CPU index not less than n or negative
+//   ErrDuplicate - CPU selected more than once
+//   ErrInvalidArg - The "n" parameter is not positive
+func ResolveCPUsFor(spec string, n int) ([]int, error) {
+	return resolveCPUs("ResolveCPUsFor", spec, n, nil)
+}
+
+// resolveCPUs implements ResolveCPUs and ResolveCPUsFor, with "fn" the name of
+// the calling function used in any error returned. Only the CPUs of allowed,
+// which must be normalized, may be selected unless it is nil.
+func resolveCPUs(fn, spec string, n int, allowed []span) ([]int, error) {
+	if n <= 0 {
+		return nil, fmt.Errorf("intlist.%s: CPU count %d: %w", fn, n, ErrInvalidArg)
+	}
+	c := &config{
+		features: extensions,
+		bounded:  true,
+		lo:       0,
+		hi:       n - 1,
+		noDups:   true,
+	}
+	if allowed != nil {
+		c.restricted, c.allowed = true, allowed
+	}
+	seqs, err := parseSpec(fn, spec, c)
+	if err != nil {
+		return nil, err
+	}
+	return (&Iterator{seqs: seqs}).rest(), nil
+}
@@ -0,0 +1,19 @@
+// Copyright 2020 Brian E. Holland. All rights reserved.
+// The use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+//go:build linux
+
+package intlist
+
+import "os"
+
+// affinity returns the sequences of the CPUs the process may run on, reporting
+// false if they can't be read.
+func affinity() ([]seq, bool) {
+	status, err := os.ReadFile("/proc/self/status")
+	if err != nil {
+		return nil, false
+	}
+	return parseAffinity(string(status))
+}
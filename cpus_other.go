@@ -0,0 +1,13 @@
+// Copyright 2020 Brian E. Holland. All rights reserved.
+// The use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+//go:build !linux
+
+package intlist
+
+// affinity reports false, as the CPUs the process may run on are only read on
+// Linux.
+func affinity() ([]seq, bool) {
+	return nil, false
+}
@@ -0,0 +1,86 @@
+// Copyright 2020 Brian E. Holland. All rights reserved.
+// The use of this source code is governed by an MIT license
+// that can be found in the LICENSE file.
+
+package intlist_test
+
+import (
+	"errors"
+	"runtime"
+	"strconv"
+	"testing"
+
+	"github.com/brianholland99/intlist"
+	"github.com/google/go-cmp/cmp"
+)
+
+var resolveCPUsForTests = []resolveTest{
+	// Good cases
+	{"0,2...3", 4, []int{0, 2, 3}, nil},
+	{"3...0", 4, []int{3, 2, 1, 0}, nil}, // Order is kept
+	{"", 4, []int{}, nil},
+	// Error cases
+	{"4", 4, nil, intlist.ErrOutOfBounds},
+	{"-1", 4, nil, intlist.ErrOutOfBounds},
+	{"0...2,1", 4, nil, intlist.ErrDuplicate},
+	{"0", 0, nil, intlist.ErrInvalidArg},
+	{"0..2", 4, nil, strconv.ErrSyntax},
+}
+
+func TestResolveCPUsFor(t *testing.T) {
+	for _, test := range resolveCPUsForTests {
+		out, err := intlist.ResolveCPUsFor(test.in, test.n)
+		if !cmp.Equal(out, test.out) || !errors.Is(err, test.err) {
+			t.Errorf("ResolveCPUsFor(%q, %d) = (%v), (%v) -- wanted (%v), (%v)",
+				test.in, test.n, out, err, test.out, test.err)
+		}
+	}
+}
+
+func TestResolveCPUs(t *testing.T) {
+	spec, ok := intlist.AllowedCPUs()
+	if !ok {
+		spec = "0..." + strconv.Itoa(runtime.NumCPU()-1)
+	}
+	cpus, err := intlist.ResolveCPUs(spec)
+	if err != nil {
+		t.Fatalf("ResolveCPUs(%q) error = %v -- wanted nil", spec, err)
+	}
+	last := 0 // Highest CPU allowed
+	for _, cpu := range cpus {
+		if cpu > last {
+			last = cpu
+		}
+	}
+	past := strconv.Itoa(last + 1)
+	if _, err := intlist.ResolveCPUs(past); !errors.Is(err, intlist.ErrOutOfBounds) {
+		t.Errorf("ResolveCPUs(%q) error = %v -- wanted %v", past, err, intlist.ErrOutOfBounds)
+	}
+	// A CPU missing from the affinity mask is not allowed.
+	if hole, err := intlist.Subtract("0..."+strconv.Itoa(last), spec); ok && err == nil && hole != "" {
+		if _, err := intlist.ResolveCPUs(hole); !errors.Is(err, intlist.ErrNotAllowed) {
+			t.Errorf("ResolveCPUs(%q) error = %v -- wanted %v", hole, err, intlist.ErrNotAllowed)
+		}
+	}
+}
+
+var parseAffinityTests = []struct {
+	status string
+	out    string
+	ok     bool
+}{
+	{"Name:\tsh\nCpus_allowed:\t10f\nCpus_allowed_list:\t0-3,8\n", "0...3,8", true},
+	{"Cpus_allowed_list:\t5\n", "5", true},
+	{"Name:\tsh\n", "", false},
+	{"Cpus_allowed_list:\t\n", "", false},
+	{"Cpus_allowed_list:\t0-\n", "", false},
+}
+
+func TestParseAffinity(t *testing.T) {
+	for _, test := range parseAffinityTests {
+		if out, ok := intlist.ParseAffinity(test.status); out != test.out || ok != test.ok {
+			t.Errorf("parseAffinity(%q) = (%q), (%v) -- wanted (%q), (%v)",
+				test.status, out, ok, test.out, test.ok)
+		}
+	}
+}
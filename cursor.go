@@ -0,0 +1,57 @@
+// Copyright 2020 Brian E. Holland. All rights reserved.
+// The use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package intlist
+
+// Cursor is a position in the values of a List that can move both forwards
+// and backwards, such as for browsing that pages back. Like a text cursor, it
+// sits between two values, so Prev after Next returns the same value again.
+type Cursor struct {
+	seqs []seq  // Sequences of the List
+	i    int    // Index in seqs of the sequence of the value after the cursor
+	k    uint64 // Position (origin 0) in seqs[i] of the value after the cursor
+}
+
+// Cursor returns a new Cursor before the first value of l.
+//
+//   c := list.Cursor() // For the List of "1...3"
+//   c.Next() -> 1, nil
+//   c.Next() -> 2, nil
+//   c.Prev() -> 2, nil
+//   c.Prev() -> 1, nil
+//   c.Prev() -> 0, ErrDone
+func (l *List) Cursor() *Cursor {
+	return &Cursor{seqs: l.sequences()} // Cursor does not change the sequences.
+}
+
+// Next returns the value after the cursor and moves the cursor past it. If
+// the cursor is after the last value, ErrDone is returned and the cursor
+// stays there.
+func (c *Cursor) Next() (int, error) {
+	if c.i == len(c.seqs) {
+		return 0, ErrDone
+	}
+	s := c.seqs[c.i]
+	val := s.at(c.k)
+	c.k++
+	if c.k == s.len() {
+		c.i, c.k = c.i+1, 0
+	}
+	return val, nil
+}
+
+// Prev returns the value before the cursor and moves the cursor back before
+// it. If the cursor is before the first value, ErrDone is returned and the
+// cursor stays there.
+func (c *Cursor) Prev() (int, error) {
+	if c.k == 0 {
+		if c.i == 0 {
+			return 0, ErrDone
+		}
+		c.i--
+		c.k = c.seqs[c.i].len()
+	}
+	c.k--
+	return c.seqs[c.i].at(c.k), nil
+}
@@ -0,0 +1,67 @@
+// Copyright 2020 Brian E. Holland. All rights reserved.
+// The use of this source code is governed by an MIT license
+// that can be found in the LICENSE file.
+
+package intlist_test
+
+import (
+	"testing"
+
+	"github.com/brianholland99/intlist"
+)
+
+// cursorStep is a move of a Cursor and the value expected, or ErrDone.
+type cursorStep struct {
+	forward bool
+	out     int
+	done    bool
+}
+
+var cursorTests = []struct {
+	in    string
+	steps []cursorStep
+}{
+	{"1...3,7", []cursorStep{
+		{false, 0, true}, // Before the start
+		{true, 1, false}, {true, 2, false},
+		{false, 2, false}, {false, 1, false}, {false, 0, true},
+		{true, 1, false}, {true, 2, false}, {true, 3, false}, {true, 7, false},
+		{true, 0, true}, {true, 0, true}, // Past the end stays there
+		{false, 7, false}, {false, 3, false},
+	}},
+	{"5...4,4", []cursorStep{
+		{true, 5, false}, {true, 4, false}, {true, 4, false}, {true, 0, true},
+		{false, 4, false}, {false, 4, false}, {false, 5, false}, {false, 0, true},
+	}},
+	{"", []cursorStep{{true, 0, true}, {false, 0, true}}},
+}
+
+func TestCursor(t *testing.T) {
+	for _, test := range cursorTests {
+		list, err := intlist.Compile(test.in)
+		if err != nil {
+			t.Fatalf("Compile(%q) error = %v", test.in, err)
+		}
+		c := list.Cursor()
+		for i, step := range test.steps {
+			move, next := "Prev", c.Prev
+			if step.forward {
+				move, next = "Next", c.Next
+			}
+			out, err := next()
+			if out != step.out || (err == intlist.ErrDone) != step.done {
+				t.Errorf("%q step %d: %s() = (%v), (%v) -- wanted (%v), done %v",
+					test.in, i, move, out, err, step.out, step.done)
+			}
+		}
+	}
+	// A nil List has no values either way.
+	var none *intlist.List
+	c := none.Cursor()
+	if _, err := c.Next(); err != intlist.ErrDone {
+		t.Errorf("nil List Cursor().Next() error = %v -- wanted %v", err, intlist.ErrDone)
+	}
+	if _, err := c.Prev(); err != intlist.ErrDone {
+		t.Errorf("nil List Cursor().Prev() error = %v -- wanted %v", err, intlist.ErrDone)
+	}
+}
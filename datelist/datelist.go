@@ -0,0 +1,135 @@
+// Copyright 2020 Brian E. Holland. All rights reserved.
+// The use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+// Package datelist supports the intlist notation for series of calendar days,
+// such as the days a scheduled job runs.
+//
+// Format:
+//   - Comma-separated expressions of dates or date sequences.
+//   - An empty string indicates an empty list.
+//   - Dates are written as year-month-day (E.g., "2024-02-14").
+//   - Sequences are consecutive days notated by two endpoints separated by
+//     an ellipsis and includes both endpoints.
+//   - Both increasing and decreasing sequences are supported.
+//
+// Examples:
+//
+//   spec = "2024-01-30...2024-02-01,2024-02-14" -->
+//       [2024-01-30, 2024-01-31, 2024-02-01, 2024-02-14]
+//
+// Days are counted as integers and expanded by an intlist.Iterator, so an
+// Iterator never holds the whole expansion. Values are midnight UTC.
+package datelist
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/brianholland99/intlist"
+)
+
+// layout is the format of a date.
+const layout = "2006-01-02"
+
+// secondsPerDay is the length of a day in UTC.
+const secondsPerDay = 24 * 60 * 60
+
+// Iterator is the state for generating days from a datelist description.
+type Iterator struct {
+	it  *intlist.Iterator // Source of the day numbers
+	err error             // Error in creating, if any
+}
+
+// NewIterator validates the specification and sets the state for iteration.
+//
+//   NewIterator("2024-02-28...2024-03-01") ->
+//       [2024-02-28 2024-02-29 2024-03-01] (midnight UTC)
+//
+// Potential errors set in state during creation of an Iterator:
+//
+//   strconv.ErrSyntax - Error parsing date or sequence notation
+func NewIterator(spec string) *Iterator {
+	days, err := toIntSpec("NewIterator", spec)
+	if err != nil {
+		return &Iterator{err: err}
+	}
+	return &Iterator{it: intlist.NewIterator(days)}
+}
+
+// Next returns the next day if not done and an error to indicate if done.
+//
+// If intlist.ErrDone is returned the time is not valid and there are no more
+// days.
+//
+// It will panic for the following avoidable cases:
+//   - Next called on invalid iterator.
+//   - Next called after previous call to Next() returned ErrDone.
+func (i *Iterator) Next() (time.Time, error) {
+	if i.err != nil {
+		panic("Next() called on invalid iterator.")
+	}
+	day, err := i.it.Next()
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.Unix(int64(day)*secondsPerDay, 0).UTC(), nil
+}
+
+// Err returns any error that occured when creating this Iterator.
+func (i *Iterator) Err() error {
+	return i.err
+}
+
+// Done reports whether a previous Next call returned intlist.ErrDone to
+// indicate that the end of the iteration occurred.
+func (i *Iterator) Done() bool {
+	return i.err == nil && i.it.Done()
+}
+
+// Parse returns the days represented by the passed specification.
+//
+//   Parse("2024-01-31...2024-02-01") -> [2024-01-31 2024-02-01], nil
+//
+// Potential errors returned are those of NewIterator.
+func Parse(spec string) ([]time.Time, error) {
+	it := NewIterator(spec)
+	if it.Err() != nil {
+		return nil, it.Err()
+	}
+	result := []time.Time{}
+	for {
+		t, err := it.Next()
+		if err == intlist.ErrDone {
+			return result, nil
+		}
+		result = append(result, t)
+	}
+}
+
+// toIntSpec returns the intlist specification of the day numbers of spec,
+// counted from 1970-01-01. The "fn" parameter is the name of the calling
+// function used in any error returned.
+func toIntSpec(fn, spec string) (string, error) {
+	if spec == "" {
+		return "", nil
+	}
+	items := strings.Split(spec, ",")
+	for i, item := range items {
+		ends := strings.Split(item, "...")
+		if len(ends) > 2 {
+			return "", &strconv.NumError{Func: fn, Num: item, Err: strconv.ErrSyntax}
+		}
+		for j, end := range ends {
+			t, err := time.Parse(layout, end)
+			if err != nil {
+				return "", &strconv.NumError{Func: fn, Num: item, Err: strconv.ErrSyntax}
+			}
+			// Whole days, as the time is midnight UTC
+			ends[j] = strconv.FormatInt(t.Unix()/secondsPerDay, 10)
+		}
+		items[i] = strings.Join(ends, "...")
+	}
+	return strings.Join(items, ","), nil
+}
@@ -0,0 +1,76 @@
+// Copyright 2020 Brian E. Holland. All rights reserved.
+// The use of this source code is governed by an MIT license
+// that can be found in the LICENSE file.
+
+package datelist_test
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/brianholland99/intlist/datelist"
+	"github.com/google/go-cmp/cmp"
+)
+
+var parseTests = []struct {
+	in  string
+	out []string // Values formatted as dates
+	err error
+}{
+	// Good cases
+	{"2024-01-30...2024-02-01,2024-02-14",
+		[]string{"2024-01-30", "2024-01-31", "2024-02-01", "2024-02-14"}, nil},
+	{"2024-02-28...2024-03-01", []string{"2024-02-28", "2024-02-29", "2024-03-01"},
+		nil}, // Leap day
+	{"1970-01-01...1969-12-30", []string{"1970-01-01", "1969-12-31", "1969-12-30"},
+		nil}, // Decreasing and before 1970
+	{"2023-12-31", []string{"2023-12-31"}, nil},
+	{"", []string{}, nil}, // Empty list
+	// Error cases
+	{"2023-02-29", nil, strconv.ErrSyntax}, // No such day
+	{"2024-1-5", nil, strconv.ErrSyntax},   // Short fields
+	{"2024-01-01..2024-01-03", nil, strconv.ErrSyntax},
+	{"2024-01-01...2024-01-02...2024-01-03", nil, strconv.ErrSyntax},
+	{"2024-01-01,", nil, strconv.ErrSyntax},
+}
+
+func TestParse(t *testing.T) {
+	for _, test := range parseTests {
+		days, err := datelist.Parse(test.in)
+		var out []string
+		if days != nil {
+			out = []string{}
+		}
+		for _, day := range days {
+			if day.Location() != time.UTC || day.Hour() != 0 {
+				t.Errorf("Parse(%q) gave %v -- wanted midnight UTC", test.in, day)
+			}
+			out = append(out, day.Format("2006-01-02"))
+		}
+		if !cmp.Equal(out, test.out) || !errors.Is(err, test.err) {
+			t.Errorf("Parse(%q) = (%v), (%v) -- wanted (%v), (%v)",
+				test.in, out, err, test.out, test.err)
+		}
+	}
+}
+
+func TestIterator(t *testing.T) {
+	it := datelist.NewIterator("2024-12-31...2025-01-01")
+	var out []string
+	for {
+		day, err := it.Next()
+		if err != nil {
+			break
+		}
+		out = append(out, day.Format("2006-01-02"))
+	}
+	if want := []string{"2024-12-31", "2025-01-01"}; !cmp.Equal(out, want) || !it.Done() {
+		t.Errorf("Iterator = %v, Done() = %v -- wanted %v, true", out, it.Done(), want)
+	}
+	if it := datelist.NewIterator("tomorrow"); !errors.Is(it.Err(), strconv.ErrSyntax) {
+		t.Errorf("NewIterator(%q) Err() = %v -- wanted %v", "tomorrow", it.Err(),
+			strconv.ErrSyntax)
+	}
+}
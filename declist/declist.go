@@ -0,0 +1,184 @@
+// Copyright 2020 Brian E. Holland. All rights reserved.
+// The use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+// Package declist supports the intlist notation for series of decimal
+// numbers, such as fractional thresholds in a configuration file. Values are
+// exact, using big.Rat, so sequences never drift as floating point would.
+//
+// Format:
+//   - Comma-separated expressions of decimal numbers or sequences.
+//   - An empty string indicates an empty list.
+//   - Numbers are written with an optional sign and decimal point (E.g.,
+//     "-0.25" or "3").
+//   - Sequences are notated by two endpoints separated by an ellipsis and
+//     an optional step after a colon (E.g., "0.5...2.5:0.5"). The step is 1
+//     if not given and must be positive. Sequences start with the first
+//     endpoint and go toward the second, which is only included if a whole
+//     number of steps reaches it.
+//   - Both increasing and decreasing sequences are supported.
+//
+// Examples:
+//
+//   spec = "0.5...2.5:0.5" --> [0.5, 1, 1.5, 2, 2.5]
+//   spec = "1...0:0.3,7" --> [1, 0.7, 0.4, 0.1, 7]
+package declist
+
+import (
+	"math/big"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/brianholland99/intlist"
+)
+
+// decimalPattern matches a decimal number.
+var decimalPattern = regexp.MustCompile(`^[+-]?\d+(?:\.\d+)?$`)
+
+// seq is a sequence of values or a single value, which has a step of 0.
+type seq struct {
+	next *big.Rat // Next value to retrieve
+	last *big.Rat // Limit of the sequence
+	step *big.Rat // Signed difference between values
+}
+
+// Iterator is the state for generating values from a declist description.
+type Iterator struct {
+	seqs []seq // Remaining sequences to handle
+	err  error // Error in creating, if any
+	done bool  // Next has returned ErrDone
+}
+
+// NewIterator validates the specification and sets the state for iteration.
+//
+//   NewIterator("0.5...2.5:0.5") -> [1/2 1 3/2 2 5/2]
+//
+// Potential errors set in state during creation of an Iterator:
+//
+//   strconv.ErrSyntax - Error parsing number, sequence or step notation,
+//       including a step that is not positive
+func NewIterator(spec string) *Iterator {
+	seqs, err := parseSpec("NewIterator", spec)
+	return &Iterator{seqs: seqs, err: err}
+}
+
+// Next returns the next value if not done and an error to indicate if done.
+// Each value returned is a new big.Rat owned by the caller.
+//
+// If intlist.ErrDone is returned the value is nil and there are no more
+// values.
+//
+// It will panic for the following avoidable cases:
+//   - Next called on invalid iterator.
+//   - Next called after previous call to Next() returned ErrDone.
+func (i *Iterator) Next() (*big.Rat, error) {
+	if i.err != nil {
+		panic("Next() called on invalid iterator.")
+	}
+	if i.done {
+		// Caller was already informed that iterator was done.
+		panic("Next() called again after returning ErrDone.")
+	}
+	if len(i.seqs) == 0 {
+		i.done = true
+		return nil, intlist.ErrDone
+	}
+	s := &i.seqs[0] // Current sequence being handled
+	val := new(big.Rat).Set(s.next)
+	s.next.Add(s.next, s.step)
+	if s.step.Sign() == 0 || s.next.Cmp(s.last) == s.step.Sign() {
+		i.seqs = i.seqs[1:] // Past the limit, so done with this sequence.
+	}
+	return val, nil
+}
+
+// Err returns any error that occured when creating this Iterator.
+func (i *Iterator) Err() error {
+	return i.err
+}
+
+// Done reports whether a previous Next call returned intlist.ErrDone to
+// indicate that the end of the iteration occurred.
+func (i *Iterator) Done() bool {
+	return i.done
+}
+
+// Parse returns the values represented by the passed specification.
+//
+//   Parse("1...0:0.3,7") -> [1 7/10 2/5 1/10 7], nil
+//
+// Potential errors returned are those of NewIterator.
+func Parse(spec string) ([]*big.Rat, error) {
+	it := NewIterator(spec)
+	if it.Err() != nil {
+		return nil, it.Err()
+	}
+	result := []*big.Rat{}
+	for {
+		val, err := it.Next()
+		if err == intlist.ErrDone {
+			return result, nil
+		}
+		result = append(result, val)
+	}
+}
+
+// parseSpec parses spec into its sequences. The "fn" parameter is the name of
+// the calling function used in any error returned.
+func parseSpec(fn, spec string) ([]seq, error) {
+	seqs := []seq{}
+	if spec == "" {
+		return seqs, nil
+	}
+	for _, item := range strings.Split(spec, ",") {
+		s, ok := parseItem(item)
+		if !ok {
+			return nil, &strconv.NumError{Func: fn, Num: item, Err: strconv.ErrSyntax}
+		}
+		seqs = append(seqs, s)
+	}
+	return seqs, nil
+}
+
+// parseItem parses a number or sequence. It reports false if item is
+// malformed.
+func parseItem(item string) (seq, bool) {
+	rng, stepText, hasStep := strings.Cut(item, ":")
+	ends := strings.Split(rng, "...")
+	if len(ends) > 2 || (hasStep && len(ends) == 1) {
+		return seq{}, false
+	}
+	var s seq
+	var ok bool
+	if s.next, ok = decimal(ends[0]); !ok {
+		return seq{}, false
+	}
+	s.last, s.step = new(big.Rat).Set(s.next), new(big.Rat)
+	if len(ends) == 1 {
+		return s, true
+	}
+	if s.last, ok = decimal(ends[1]); !ok {
+		return seq{}, false
+	}
+	s.step.SetInt64(1)
+	if hasStep {
+		if s.step, ok = decimal(stepText); !ok || s.step.Sign() <= 0 {
+			return seq{}, false
+		}
+	}
+	if s.next.Cmp(s.last) > 0 {
+		s.step.Neg(s.step)
+	} else if s.next.Cmp(s.last) == 0 {
+		s.step.SetInt64(0)
+	}
+	return s, true
+}
+
+// decimal parses a decimal number. It reports false if tok is not one.
+func decimal(tok string) (*big.Rat, bool) {
+	if !decimalPattern.MatchString(tok) {
+		return nil, false
+	}
+	return new(big.Rat).SetString(tok)
+}
@@ -0,0 +1,73 @@
+// Copyright 2020 Brian E. Holland. All rights reserved.
+// The use of this source code is governed by an MIT license
+// that can be found in the LICENSE file.
+
+package declist_test
+
+import (
+	"errors"
+	"math/big"
+	"strconv"
+	"testing"
+
+	"github.com/brianholland99/intlist"
+	"github.com/brianholland99/intlist/declist"
+	"github.com/google/go-cmp/cmp"
+)
+
+var parseTests = []struct {
+	in  string
+	out []string // Values as fractions
+	err error
+}{
+	// Good cases
+	{"0.5...2.5:0.5", []string{"1/2", "1/1", "3/2", "2/1", "5/2"}, nil},
+	{"1...0:0.3,7", []string{"1/1", "7/10", "2/5", "1/10", "7/1"}, nil}, // Decreasing
+	{"1...3", []string{"1/1", "2/1", "3/1"}, nil},                       // Default step
+	{"0.1...0.35:0.1", []string{"1/10", "1/5", "3/10"}, nil},            // End not reached
+	{"-0.25,+2.50", []string{"-1/4", "5/2"}, nil},                       // Signs
+	{"2...2:0.1", []string{"2/1"}, nil},                                 // Single
+	{"0.1...0.3:1", []string{"1/10"}, nil},                              // Step past end
+	{"", []string{}, nil},                                               // Empty list
+	// Error cases
+	{"1...2:0", nil, strconv.ErrSyntax},    // Zero step
+	{"1...2:-0.5", nil, strconv.ErrSyntax}, // Negative step
+	{"1:0.5", nil, strconv.ErrSyntax},      // Step without sequence
+	{"1..2", nil, strconv.ErrSyntax},
+	{"1...2...3", nil, strconv.ErrSyntax},
+	{".5", nil, strconv.ErrSyntax},
+	{"1/2", nil, strconv.ErrSyntax},
+	{"1e3", nil, strconv.ErrSyntax},
+	{"1,", nil, strconv.ErrSyntax},
+}
+
+func TestParse(t *testing.T) {
+	for _, test := range parseTests {
+		vals, err := declist.Parse(test.in)
+		var out []string
+		if vals != nil {
+			out = []string{}
+		}
+		for _, v := range vals {
+			out = append(out, v.String())
+		}
+		if !cmp.Equal(out, test.out) || !errors.Is(err, test.err) {
+			t.Errorf("Parse(%q) = (%v), (%v) -- wanted (%v), (%v)",
+				test.in, out, err, test.out, test.err)
+		}
+	}
+}
+
+// Values returned must not change as iteration continues.
+func TestIteratorOwnership(t *testing.T) {
+	it := declist.NewIterator("0...1:0.5")
+	first, _ := it.Next()
+	for {
+		if _, err := it.Next(); err == intlist.ErrDone {
+			break
+		}
+	}
+	if first.Cmp(new(big.Rat)) != 0 || !it.Done() {
+		t.Errorf("first value = %v, Done() = %v -- wanted 0, true", first, it.Done())
+	}
+}
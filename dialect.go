@@ -0,0 +1,100 @@
+// Copyright 2020 Brian E. Holland. All rights reserved.
+// The use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package intlist
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Dialect is a notation for the sequences of a specification.
+type Dialect int
+
+// The notations for sequences.
+const (
+	DialectEllipsis  Dialect = iota // Endpoints separated by "..." ("1...5")
+	DialectPageRange                // Endpoints separated by "-" ("1-5")
+)
+
+// rangeSeps are the separators of the endpoints of a sequence in each Dialect.
+var rangeSeps = []string{"...", "-"}
+
+// WithDialect sets the notation for sequences, for specifications from tools
+// that never use the "..." notation. See ParseDialect.
+//
+//   ParseWithOptions("1-3,8", WithDialect(DialectPageRange)) -> [1 2 3 8], nil
+func WithDialect(d Dialect) Option {
+	return func(c *config) {
+		c.dialect = d
+	}
+}
+
+// ParseDialect is Parse for specifications using the passed notation for
+// sequences. DialectPageRange accepts the notation of print dialogs, cut(1)
+// and sacct (E.g., "1-5,8,11-13") in place of "...". The rest of the syntax is
+// that of Parse, so steps ("0-15:4") need WithDialect and WithFeatures.
+//
+// A hyphen right after a digit separates the endpoints of a sequence and any
+// other hyphen is a minus sign. So "-3--1" is -3 through -1, "5--2" is 5 down
+// through -2, and "-5" is the single value -5, not an open range as in cut(1).
+// A hyphen at the end of an item ("5-") is an error.
+//
+//   ParseDialect("1-5,8,11-13", DialectPageRange) ->
+//       [1 2 3 4 5 8 11 12 13], nil
+//   ParseDialect("-3--1", DialectPageRange) -> [-3 -2 -1], nil
+//   ParseDialect("1...5", DialectPageRange) -> nil, strconv.ErrSyntax
+//
+// Potential errors returned:
+//
+//   strconv.ErrSyntax - Error parsing integer or sequence notation
+//   strconv.ErrRange - Integer out of range
+//   ErrFeature - Syntax extension used
+//   ErrInvalidArg - Unknown Dialect
+func ParseDialect(spec string, d Dialect) ([]int, error) {
+	seqs, err := parseSpec("ParseDialect", spec, &config{dialect: d})
+	if err != nil {
+		return nil, err
+	}
+	return (&Iterator{seqs: seqs}).rest(), nil
+}
+
+// checkDialect reports an error if c has an unknown Dialect. The "fn"
+// parameter is the name of the calling function used in any error returned.
+func (c *config) checkDialect(fn string) error {
+	if c.dialect < 0 || int(c.dialect) >= len(rangeSeps) {
+		return fmt.Errorf("intlist.%s: dialect %d: %w", fn, c.dialect, ErrInvalidArg)
+	}
+	return nil
+}
+
+// rangeSep returns the separator of the endpoints of a sequence for c.
+func (c *config) rangeSep() string {
+	return rangeSeps[c.dialect]
+}
+
+// rangeParts splits an item at the separators of the endpoints of a sequence.
+func (c *config) rangeParts(item string) []string {
+	if c.dialect != DialectPageRange {
+		return strings.Split(item, "...")
+	}
+	var parts []string
+	start := 0
+	for i := 1; i < len(item); i++ {
+		if item[i] == '-' && afterDigit(item[:i], c.lenient) {
+			parts = append(parts, item[start:i])
+			start = i + 1
+		}
+	}
+	return append(parts, item[start:])
+}
+
+// afterDigit reports whether s ends with a digit, ignoring any trailing
+// whitespace if lenient.
+func afterDigit(s string, lenient bool) bool {
+	if lenient {
+		s = strings.TrimRight(s, " \t\r\n")
+	}
+	return s != "" && s[len(s)-1] >= '0' && s[len(s)-1] <= '9'
+}
@@ -0,0 +1,70 @@
+// Copyright 2020 Brian E. Holland. All rights reserved.
+// The use of this source code is governed by an MIT license
+// that can be found in the LICENSE file.
+
+package intlist_test
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/brianholland99/intlist"
+	"github.com/google/go-cmp/cmp"
+)
+
+type parseDialectTest struct {
+	in      string
+	dialect intlist.Dialect
+	out     []int
+	err     error
+}
+
+var parseDialectTests = []parseDialectTest{
+	// Good cases
+	{"1-5,8,11-13", intlist.DialectPageRange, []int{1, 2, 3, 4, 5, 8, 11, 12, 13}, nil},
+	{"-3--1", intlist.DialectPageRange, []int{-3, -2, -1}, nil}, // Negatives
+	{"2--1", intlist.DialectPageRange, []int{2, 1, 0, -1}, nil}, // Decreasing
+	{"-5", intlist.DialectPageRange, []int{-5}, nil},            // Not open
+	{"1...3", intlist.DialectEllipsis, []int{1, 2, 3}, nil},     // Default
+	{"", intlist.DialectPageRange, []int{}, nil},                // Empty list
+	// Error cases
+	{"1...5", intlist.DialectPageRange, nil, strconv.ErrSyntax}, // No ellipsis
+	{"1-3-5", intlist.DialectPageRange, nil, strconv.ErrSyntax}, // Two ranges
+	{"5-", intlist.DialectPageRange, nil, strconv.ErrSyntax},    // Open end
+	{"1-5", intlist.DialectEllipsis, nil, strconv.ErrSyntax},
+	{"0-15:5", intlist.DialectPageRange, nil, intlist.ErrFeature}, // Step not enabled
+	{"1", intlist.Dialect(9), nil, intlist.ErrInvalidArg},
+}
+
+func TestParseDialect(t *testing.T) {
+	for _, test := range parseDialectTests {
+		out, err := intlist.ParseDialect(test.in, test.dialect)
+		if !cmp.Equal(out, test.out) || !errors.Is(err, test.err) {
+			t.Errorf("ParseDialect(%q, %d) = (%v), (%v) -- wanted (%v), (%v)",
+				test.in, test.dialect, out, err, test.out, test.err)
+		}
+	}
+}
+
+func TestWithDialect(t *testing.T) {
+	out, err := intlist.ParseWithOptions(" 1 - 3, 8", intlist.WithLenientWhitespace(),
+		intlist.WithDialect(intlist.DialectPageRange))
+	if want := []int{1, 2, 3, 8}; !cmp.Equal(out, want) || err != nil {
+		t.Errorf("ParseWithOptions(%q) = (%v), (%v) -- wanted (%v), (nil)",
+			" 1 - 3, 8", out, err, want)
+	}
+	out, err = intlist.ParseWithOptions("0-15:5", intlist.WithDialect(intlist.DialectPageRange),
+		intlist.WithFeatures(intlist.FeatureStep))
+	if want := []int{0, 5, 10, 15}; !cmp.Equal(out, want) || err != nil {
+		t.Errorf("ParseWithOptions(%q) = (%v), (%v) -- wanted (%v), (nil)",
+			"0-15:5", out, err, want)
+	}
+	// Suggestions use the separator of the dialect.
+	_, err = intlist.ParseDialect("1..5", intlist.DialectPageRange)
+	var parseErr *intlist.ParseError
+	if !errors.As(err, &parseErr) || parseErr.Suggestion != "1-5" {
+		t.Errorf("ParseDialect(%q) error = %v -- wanted suggestion %q",
+			"1..5", err, "1-5")
+	}
+}
@@ -14,10 +14,23 @@
 //   - Sequences are consecutive integers notated by two endpoints
 //     separated by an ellipsis and includes both endpoints.
 //   - Both increasing and decreasing sequences are supported.
+//   - With FeatureStep, a sequence may end with a colon and a positive step
+//     to take every step-th integer from the first endpoint. The second
+//     endpoint is included only if it is reached.
+//   - With FeatureRepeat, an integer followed by "x" and a positive count is
+//     repeated that many times (E.g., "0x3" is three zeros).
+//   - Syntax extensions enabled using WithFeatures add other forms, such as
+//     "!" before an item to remove its integers from the whole list. An
+//     exclusion with a step may leave the rest as at most 65536 sequences
+//     ("0...12,!0...12:3" leaves four), or it is an error (ErrLimit).
+//   - ParseDialect or WithDialect accept "-" in place of the ellipsis
+//     (E.g., "1-5,8"), as written by printers, cut(1) and sacct.
 //
 // Examples:
 //   spec = "4,6,10...15" --> [4, 6, 10, 11, 12, 13, 14, 15]
 //   spec = "4,12...8,-3" --> [4, 12, 11, 10, 9, 8, -3]
+//   spec = "0...10:5,10...0:4" --> [0, 5, 10, 10, 6, 2]
+//   spec = "7x3,1" --> [7, 7, 7, 1]
 //
 // There are two supported use cases; creating an int slice and an Iterator to
 // produce the ints as needed.
@@ -25,10 +38,15 @@
 // "Parse" will parse a string and return a integer slice. This is useful when
 // a slice is wanted and the size of the result is not too large.
 //
-// "NewIterator" / "Next" / "Err" functions - provide the functionality
-// necessary to iterate through the list of integers. This may be especially
-// useful when the resulting list is too huge or when it is possible to stop
-// before using the whole list.
+// "NewIterator" / "Next" / "Err" / "Done" functions - provide the
+// functionality necessary to iterate through the list of integers. This may be
+// especially useful when the resulting list is too huge or when it is possible
+// to stop before using the whole list. With Go 1.23 or later, "Values" gives
+// the same values for use in a range loop.
+//
+// "ParseWithOptions" / "NewIteratorWithOptions" functions - are the same as
+// the above but take Options (E.g., WithLenientWhitespace, WithBounds or
+// WithMaxItems) changing how the specification is parsed and validated.
 //
 // Example of iterator usage:
 //
@@ -0,0 +1,111 @@
+// Copyright 2020 Brian E. Holland. All rights reserved.
+// The use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package intlist
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// ParseError is returned when more is known about a failure to parse an item
+// than the underlying error holds. It wraps that error, so errors.Is(err,
+// strconv.ErrSyntax) and errors.As(err, &numErr) still work.
+type ParseError struct {
+	Err        error  // Underlying error (E.g., a *strconv.NumError)
+	Suggestion string // Likely intended item or "" if none
+	Line       int    // Line (origin 1) of the item or 0 if not tracked
+	Column     int    // Column (origin 1, in runes) of the item if Line > 0
+}
+
+// Error returns the underlying error message preceded by any position and
+// followed by any suggestion.
+func (e *ParseError) Error() string {
+	msg := e.Err.Error()
+	if e.Line > 0 {
+		msg = fmt.Sprintf("line %d, column %d: %s", e.Line, e.Column, msg)
+	}
+	if e.Suggestion != "" {
+		msg += " (did you mean " + strconv.Quote(e.Suggestion) + "?)"
+	}
+	return msg
+}
+
+// Unwrap returns the underlying error.
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// IndexError is returned by ValidateIndices when items of a specification
+// select values that are not valid indices. It wraps ErrOutOfBounds, so
+// errors.Is(err, ErrOutOfBounds) works.
+type IndexError struct {
+	Func  string   // Name of the function returning the error
+	Len   int      // Length of the collection being indexed
+	Items []string // Offending items in the order of the specification
+}
+
+// Error lists the offending items.
+func (e *IndexError) Error() string {
+	quoted := make([]string, len(e.Items))
+	for i, item := range e.Items {
+		quoted[i] = strconv.Quote(item)
+	}
+	return fmt.Sprintf("intlist.%s: items %s out of bounds for length %d",
+		e.Func, strings.Join(quoted, ", "), e.Len)
+}
+
+// Unwrap returns ErrOutOfBounds.
+func (e *IndexError) Unwrap() error {
+	return ErrOutOfBounds
+}
+
+// typoPattern matches an item that looks like a sequence written with the
+// wrong separator (E.g., "1..5", "1…5" or "1-5") or with extra whitespace.
+var typoPattern = regexp.MustCompile(
+	`^\s*([+-]?\d+)\s*(?:\.{2,}|…|-|–|—)\s*([+-]?\d+)\s*$`)
+
+// singlePattern matches an integer surrounded by whitespace.
+var singlePattern = regexp.MustCompile(`^\s*([+-]?\d+)\s*$`)
+
+// suggest returns the likely intended form of an item that failed to parse
+// or "" if there is no good guess. The "sep" parameter separates the
+// endpoints of a sequence.
+func suggest(item, sep string) string {
+	var guess string
+	if m := typoPattern.FindStringSubmatch(item); m != nil {
+		guess = m[1] + sep + m[2]
+	} else if m := singlePattern.FindStringSubmatch(item); m != nil {
+		guess = m[1]
+	}
+	if guess == item {
+		return "" // Failed for some other reason, such as a range error.
+	}
+	return guess
+}
+
+// annotate returns err wrapped in a *ParseError if there is more information
+// to add about item, which starts at offset off of spec. The position is only
+// added if spec has more than one line or c asks for positions. A suggestion
+// is only added for syntax errors.
+func (c *config) annotate(err error, spec, item string, off int) error {
+	var pe ParseError
+	if errors.Is(err, strconv.ErrSyntax) {
+		pe.Suggestion = suggest(item, c.rangeSep())
+	}
+	if c.positions || strings.Contains(spec, "\n") {
+		pe.Line = strings.Count(spec[:off], "\n") + 1
+		lineStart := strings.LastIndexByte(spec[:off], '\n') + 1
+		pe.Column = utf8.RuneCountInString(spec[lineStart:off]) + 1
+	}
+	if pe.Suggestion == "" && pe.Line == 0 {
+		return err
+	}
+	pe.Err = err
+	return &pe
+}
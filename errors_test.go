@@ -0,0 +1,90 @@
+// Copyright 2020 Brian E. Holland. All rights reserved.
+// The use of this source code is governed by an MIT license
+// that can be found in the LICENSE file.
+
+package intlist_test
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/brianholland99/intlist"
+)
+
+type suggestionTest struct {
+	in         string
+	suggestion string // "" if no *ParseError is expected
+}
+
+var suggestionTests = []suggestionTest{
+	{"1..5", "1...5"},     // Two dots
+	{"2,1....5", "1...5"}, // Four dots
+	{"1…5", "1...5"},      // Unicode ellipsis
+	{"1-5", "1...5"},      // Hyphen
+	{"-3--1", "-3...-1"},  // Hyphen with negatives
+	{"1 ... 5", "1...5"},  // Whitespace
+	{" 7", "7"},           // Whitespace around an int
+	{"1...5...9", ""},     // No good guess
+	{"a...b", ""},         // No good guess
+}
+
+func TestSuggestions(t *testing.T) {
+	for _, test := range suggestionTests {
+		_, err := intlist.Parse(test.in)
+		if !errors.Is(err, strconv.ErrSyntax) {
+			t.Errorf("Parse(%q) error = %v -- wanted strconv.ErrSyntax",
+				test.in, err)
+			continue
+		}
+		var parseErr *intlist.ParseError
+		found := errors.As(err, &parseErr)
+		if test.suggestion == "" {
+			if found {
+				t.Errorf("Parse(%q) suggested %q -- wanted none",
+					test.in, parseErr.Suggestion)
+			}
+		} else if !found || parseErr.Suggestion != test.suggestion {
+			t.Errorf("Parse(%q) error = %v -- wanted suggestion %q",
+				test.in, err, test.suggestion)
+		}
+	}
+}
+
+type positionTest struct {
+	in     string
+	opts   []intlist.Option
+	line   int
+	column int
+}
+
+var positionTests = []positionTest{
+	{"1,2,\n3,x,5", []intlist.Option{intlist.WithMultiLine()}, 2, 3},
+	{"1,\n\n  ö,", []intlist.Option{intlist.WithMultiLine()}, 3, 3},
+	{"1,\n  4...2", []intlist.Option{intlist.WithLenientWhitespace(),
+		intlist.WithAscendingOnly()}, 2, 3},
+	{"1,2,\n3,4", []intlist.Option{intlist.WithMultiLine(),
+		intlist.WithMaxItems(3)}, 2, 3},
+	{"1,2\n3,1", []intlist.Option{intlist.WithMultiLine(),
+		intlist.WithNoDuplicates()}, 2, 3},
+}
+
+func TestPositions(t *testing.T) {
+	for _, test := range positionTests {
+		_, err := intlist.ParseWithOptions(test.in, test.opts...)
+		var parseErr *intlist.ParseError
+		if !errors.As(err, &parseErr) || parseErr.Line != test.line ||
+			parseErr.Column != test.column {
+			t.Errorf("ParseWithOptions(%q) error = %v -- wanted line %d, "+
+				"column %d", test.in, err, test.line, test.column)
+		}
+	}
+}
+
+func TestIndexErrorMessage(t *testing.T) {
+	err := intlist.ValidateIndices("4,1,-1...0", 4)
+	want := `intlist.ValidateIndices: items "4", "-1...0" out of bounds for length 4`
+	if err == nil || err.Error() != want {
+		t.Errorf("ValidateIndices(%q, 4) error = %v -- wanted %s", "4,1,-1...0", err, want)
+	}
+}
@@ -0,0 +1,139 @@
+// Copyright 2020 Brian E. Holland. All rights reserved.
+// The use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package intlist
+
+import (
+	"math"
+	"strings"
+)
+
+// exclusion returns item without its leading "!" and reports whether it has
+// one, meaning its values are removed from the rest of the specification.
+func (c *config) exclusion(item string) (string, bool) {
+	tok := item
+	if c.lenient {
+		tok = strings.TrimSpace(tok)
+	}
+	if !strings.HasPrefix(tok, "!") {
+		return item, false
+	}
+	return tok[1:], true
+}
+
+// maxPieces is the most sequences that the values left after an exclusion
+// with a step may take. Each of them is kept in order, so "0...12,!0...12:3"
+// leaves a sequence between each pair of multiples of 3.
+const maxPieces = 1 << 16
+
+// excludeSeqs returns seqs without the values in excluded, along with the
+// index of the item of each remaining sequence given that of each of seqs in
+// idx. Each sequence keeps its order and repeats, so it may be split into
+// several. Exclusions with steps are applied in turn after the others, and if
+// the values left after one take more than maxPieces sequences or are more
+// than allowed by WithMaxValues, it reports false along with the index in
+// excluded of that exclusion.
+func (c *config) excludeSeqs(seqs []seq, idx []int, excluded []span) ([]seq, []int, int, bool) {
+	parts := make([][]span, len(seqs)) // Values left of each of seqs
+	for i, s := range seqs {
+		parts[i] = s.spans()
+	}
+	var runs []span // Exclusions without steps
+	for _, e := range excluded {
+		if e.step <= 1 {
+			runs = append(runs, e)
+		}
+	}
+	runs = mergeRuns(runs)
+	without := func(e []span) { // Removes the values of e from parts.
+		for i, ps := range parts {
+			var rest []span
+			for _, p := range ps {
+				rest = append(rest, uncovered(p, e)...)
+			}
+			parts[i] = rest
+		}
+	}
+	without(runs)
+	for k, e := range excluded {
+		if e.step <= 1 {
+			continue
+		}
+		// Spans are split for each common multiple of the steps, so count
+		// what is left first.
+		var pieces uint64 // Most spans left after e
+		for _, ps := range parts {
+			for _, p := range ps {
+				if pieces += piecesAfter(p, e); pieces > maxPieces {
+					return nil, nil, k, false
+				}
+			}
+		}
+		if c.maxValues > 0 {
+			var left uint64 // Values left after e
+			for _, ps := range parts {
+				for _, p := range ps {
+					if left += leftAfter(p, e); left > uint64(c.maxValues) {
+						return nil, nil, k, false
+					}
+				}
+			}
+		}
+		without([]span{e})
+	}
+	result := []seq{}
+	var resultIdx []int
+	for i, s := range seqs {
+		ps := parts[i]
+		for k := range ps {
+			if s.step < 0 { // Take the parts in decreasing order.
+				k = len(ps) - 1 - k
+			}
+			part := ps[k].seq()
+			if s.step < 0 {
+				part = part.reversed()
+			}
+			part.reps = s.reps
+			result = append(result, part)
+			resultIdx = append(resultIdx, idx[i])
+		}
+	}
+	return result, resultIdx, 0, true
+}
+
+// leftAfter returns the number of values of sp not in e, or the largest
+// uint64 for all 2^64 integers.
+func leftAfter(sp, e span) uint64 {
+	common := sp.intersect(e)
+	if len(common) == 0 && sp.len() == 0 {
+		return math.MaxUint64
+	}
+	n := sp.len() // Counting modulo 2^64 gives the right answer.
+	for _, c := range common {
+		n -= c.len()
+	}
+	return n
+}
+
+// piecesAfter returns at least the number of spans of the values of sp not in
+// e, as returned by uncovered.
+func piecesAfter(sp, e span) uint64 {
+	in, ok := sp.clip(e.lo, e.hi)
+	if !ok {
+		return 2 // Values before and after e
+	}
+	n := uint64(3) // Values before and after e and after the last of it in sp
+	stride := uint64(sp.stride())
+	for _, c := range in.intersect(e) {
+		n++ // Values before c
+		switch {
+		case c.step == 0:
+		case uint64(c.step)/stride == 2:
+			n++ // Values between those of c as one span
+		case uint64(c.step)/stride > 2:
+			n += c.len() - 1 // Values between each pair of those of c
+		}
+	}
+	return n
+}
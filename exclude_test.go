@@ -0,0 +1,106 @@
+// Copyright 2020 Brian E. Holland. All rights reserved.
+// The use of this source code is governed by an MIT license
+// that can be found in the LICENSE file.
+
+package intlist_test
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/brianholland99/intlist"
+	"github.com/google/go-cmp/cmp"
+)
+
+var exclusionTests = []parseTest{
+	// Good cases
+	{"1...10,!4...6,!9", []int{1, 2, 3, 7, 8, 10}, nil},
+	{"!2,1...3,3...1", []int{1, 3, 3, 1}, nil},     // Anywhere in the list
+	{"10...1,!3...8", []int{10, 9, 2, 1}, nil},     // Order kept
+	{"0...20:4,!5...12", []int{0, 4, 16, 20}, nil}, // Step kept
+	{"1...3,!1...3", []int{}, nil},                 // Everything removed
+	{"!5", []int{}, nil},                           // Only an exclusion
+	{"1...5,!9...7", []int{1, 2, 3, 4, 5}, nil},    // Nothing removed
+	{"1,!0...1000000000:2", []int{1}, nil},         // Long exclusion with a step
+	{"0...12,!0...12:3", []int{1, 2, 4, 5, 7, 8, 10, 11}, nil},
+	{"12...0:2,!0...12:3", []int{10, 8, 4, 2}, nil},
+	{"1...10,!1...10:2,!2...10:4", []int{4, 8}, nil},
+	// Error cases
+	{"1...5,!", nil, strconv.ErrSyntax},
+	{"1...5,!!3", nil, strconv.ErrSyntax},
+	{"1...5,!3..4", nil, strconv.ErrSyntax},
+}
+
+func TestExclusion(t *testing.T) {
+	for _, test := range exclusionTests {
+		out, err := intlist.ParseWithOptions(test.in,
+			intlist.WithFeatures(intlist.FeatureExclusion|intlist.FeatureStep))
+		if !cmp.Equal(out, test.out) || !errors.Is(err, test.err) {
+			t.Errorf("ParseWithOptions(%q, WithFeatures(FeatureExclusion|FeatureStep)) = (%v), (%v) -- "+
+				"wanted (%v), (%v)", test.in, out, err, test.out, test.err)
+		}
+	}
+	// Strict parsing rejects the extension, while a transformation accepts it.
+	if _, err := intlist.Parse("1...9,!5"); !errors.Is(err, intlist.ErrFeature) {
+		t.Errorf("Parse(%q) error = %v -- wanted %v", "1...9,!5", err, intlist.ErrFeature)
+	}
+	if out, err := intlist.Reverse("1...9,!5"); out != "9...6,4...1" || err != nil {
+		t.Errorf("Reverse(%q) = (%q), (%v) -- wanted (%q), (nil)", "1...9,!5", out, err,
+			"9...6,4...1")
+	}
+}
+
+func TestExclusionChecks(t *testing.T) {
+	opts := []intlist.Option{intlist.WithFeatures(intlist.FeatureExclusion),
+		intlist.WithBounds(1, 10), intlist.WithNoDuplicates()}
+	// Exclusions are not checked as items, while the remaining values are.
+	if out, err := intlist.ParseWithOptions("1...10,!0...3", opts...); !cmp.Equal(out,
+		[]int{4, 5, 6, 7, 8, 9, 10}) || err != nil {
+		t.Errorf("ParseWithOptions(%q) = (%v), (%v) -- wanted [4...10], (nil)", "1...10,!0...3",
+			out, err)
+	}
+	if _, err := intlist.ParseWithOptions("1...5,4...6,!5", opts...); !errors.Is(err,
+		intlist.ErrDuplicate) {
+		t.Errorf("ParseWithOptions(%q) error = %v -- wanted %v", "1...5,4...6,!5", err,
+			intlist.ErrDuplicate)
+	}
+	if _, err := intlist.ParseWithOptions("1...5,4,!4", opts...); err != nil {
+		t.Errorf("ParseWithOptions(%q) error = %v -- wanted nil", "1...5,4,!4", err)
+	}
+}
+
+// Values left after an exclusion with a step count against WithMaxValues.
+func TestExclusionLimit(t *testing.T) {
+	opts := []intlist.Option{intlist.WithFeatures(intlist.FeatureExclusion | intlist.FeatureStep),
+		intlist.WithMaxValues(1000)}
+	const spec = "0...1000000000000,!0...1000000000000:3"
+	var numErr *strconv.NumError
+	if _, err := intlist.ParseWithOptions(spec, opts...); !errors.Is(err, intlist.ErrLimit) ||
+		!errors.As(err, &numErr) || numErr.Num != "!0...1000000000000:3" {
+		t.Errorf("ParseWithOptions(%q) error = %v -- wanted %v for %q", spec, err,
+			intlist.ErrLimit, "!0...1000000000000:3")
+	}
+	if out, err := intlist.ParseWithOptions("1,!0...1000000000:2", opts...); !cmp.Equal(out,
+		[]int{1}) || err != nil {
+		t.Errorf("ParseWithOptions(%q) = (%v), (%v) -- wanted [1], (nil)", "1,!0...1000000000:2",
+			out, err)
+	}
+	// Without a limit on the values, the sequences left are limited instead.
+	for _, spec := range []string{spec, "0...1000000,!0...1000000:3"} {
+		if n, err := intlist.Count(spec); !errors.Is(err, intlist.ErrLimit) {
+			t.Errorf("Count(%q) = (%d), (%v) -- wanted %v", spec, n, err, intlist.ErrLimit)
+		}
+		if _, err := intlist.Canonicalize(spec); !errors.Is(err, intlist.ErrLimit) {
+			t.Errorf("Canonicalize(%q) error = %v -- wanted %v", spec, err, intlist.ErrLimit)
+		}
+	}
+	if n, err := intlist.Count("0...1000000000000,!0...1000000000000:2"); n != 500000000000 ||
+		err != nil {
+		t.Errorf("Count(%q) = (%d), (%v) -- wanted (500000000000), (nil)",
+			"0...1000000000000,!0...1000000000000:2", n, err)
+	}
+	if n, err := intlist.Count("1...30000,!0...30000:3"); n != 20000 || err != nil {
+		t.Errorf("Count(%q) = (%d), (%v) -- wanted (20000), (nil)", "1...30000,!0...30000:3", n, err)
+	}
+}
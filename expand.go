@@ -0,0 +1,83 @@
+// Copyright 2020 Brian E. Holland. All rights reserved.
+// The use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package intlist
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// MaxJoinValues is the limit on the number of values that ExpandJoin and
+// ExpandFormat expand, which keeps a small specification from building a huge
+// string or slice.
+const MaxJoinValues = 1 << 20
+
+// ExpandFormat returns the values of the passed specification each formatted
+// using layout, such as generating names from a range of numbers. The layout
+// is used as with fmt.Sprintf with the value as its only argument, so it must
+// hold exactly one verb for an integer.
+//
+//   ExpandFormat("1...3,10", "shard-%04d") ->
+//       ["shard-0001" "shard-0002" "shard-0003" "shard-0010"], nil
+//
+// Potential errors returned:
+//
+//   strconv.ErrSyntax - Error parsing integer or sequence notation
+//   strconv.ErrRange - Integer out of range
+//   ErrInvalidArg - The "layout" parameter is not a layout for one integer
+//   ErrLimit - More than MaxJoinValues values
+func ExpandFormat(spec, layout string) ([]string, error) {
+	const fn = "ExpandFormat"
+	if s := fmt.Sprintf(layout, 0); strings.Contains(s, "%!") {
+		return nil, fmt.Errorf("intlist.%s: layout %q: %w", fn, layout, ErrInvalidArg)
+	}
+	c := &config{features: extensions, maxValues: MaxJoinValues}
+	seqs, err := parseSpec(fn, spec, c)
+	if err != nil {
+		return nil, err
+	}
+	it := &Iterator{seqs: seqs}
+	result := []string{}
+	for {
+		val, err := it.Next()
+		if err == ErrDone {
+			break
+		}
+		result = append(result, fmt.Sprintf(layout, val))
+	}
+	return result, nil
+}
+
+// ExpandJoin returns the values of the passed specification as one string
+// with sep between them, such as for query strings or command arguments.
+//
+//   ExpandJoin("1...3,10", "&id=") -> "1&id=2&id=3&id=10", nil
+//
+// Potential errors returned:
+//
+//   strconv.ErrSyntax - Error parsing integer or sequence notation
+//   strconv.ErrRange - Integer out of range
+//   ErrLimit - More than MaxJoinValues values
+func ExpandJoin(spec, sep string) (string, error) {
+	c := &config{features: extensions, maxValues: MaxJoinValues}
+	seqs, err := parseSpec("ExpandJoin", spec, c)
+	if err != nil {
+		return "", err
+	}
+	it := &Iterator{seqs: seqs}
+	var b strings.Builder
+	for first := true; ; first = false {
+		val, err := it.Next()
+		if err == ErrDone {
+			break
+		}
+		if !first {
+			b.WriteString(sep)
+		}
+		b.WriteString(strconv.Itoa(val))
+	}
+	return b.String(), nil
+}
@@ -0,0 +1,69 @@
+// Copyright 2020 Brian E. Holland. All rights reserved.
+// The use of this source code is governed by an MIT license
+// that can be found in the LICENSE file.
+
+package intlist_test
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/brianholland99/intlist"
+	"github.com/google/go-cmp/cmp"
+)
+
+var expandFormatTests = []struct {
+	in     string
+	layout string
+	out    []string
+	err    error
+}{
+	// Good cases
+	{"1...3,10", "shard-%04d", []string{"shard-0001", "shard-0002", "shard-0003", "shard-0010"}, nil},
+	{"255,16", "%#x", []string{"0xff", "0x10"}, nil},
+	{"-1,2", "%+d%%", []string{"-1%", "+2%"}, nil}, // Literal percent
+	{"", "%d", []string{}, nil},
+	// Error cases
+	{"1", "host", nil, intlist.ErrInvalidArg},  // No verb
+	{"1", "%d-%d", nil, intlist.ErrInvalidArg}, // Two verbs
+	{"1", "%s", nil, intlist.ErrInvalidArg},    // Not for an integer
+	{"1..3", "%d", nil, strconv.ErrSyntax},
+	{"1...1048577", "%d", nil, intlist.ErrLimit}, // One over the limit
+}
+
+func TestExpandFormat(t *testing.T) {
+	for _, test := range expandFormatTests {
+		out, err := intlist.ExpandFormat(test.in, test.layout)
+		if !cmp.Equal(out, test.out) || !errors.Is(err, test.err) {
+			t.Errorf("ExpandFormat(%q, %q) = (%q), (%v) -- wanted (%q), (%v)",
+				test.in, test.layout, out, err, test.out, test.err)
+		}
+	}
+}
+
+var expandJoinTests = []struct {
+	in  string
+	sep string
+	out string
+	err error
+}{
+	// Good cases
+	{"1...3,10", "&id=", "1&id=2&id=3&id=10", nil},
+	{"3...1", " ", "3 2 1", nil},
+	{"7", ",", "7", nil},
+	{"", ",", "", nil},
+	// Error cases
+	{"1...1048577", ",", "", intlist.ErrLimit}, // One over the limit
+	{"1..3", ",", "", strconv.ErrSyntax},
+}
+
+func TestExpandJoin(t *testing.T) {
+	for _, test := range expandJoinTests {
+		out, err := intlist.ExpandJoin(test.in, test.sep)
+		if out != test.out || !errors.Is(err, test.err) {
+			t.Errorf("ExpandJoin(%q, %q) = (%q), (%v) -- wanted (%q), (%v)",
+				test.in, test.sep, out, err, test.out, test.err)
+		}
+	}
+}
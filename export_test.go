@@ -0,0 +1,22 @@
+// Copyright 2020 Brian E. Holland. All rights reserved.
+// The use of this source code is governed by an MIT license
+// that can be found in the LICENSE file.
+
+package intlist
+
+// NormalizeChunk lets tests force NormalizeStream to use temporary files.
+var NormalizeChunk = &normalizeChunk
+
+// AllowedCPUs returns the specification of the CPUs the process may run on,
+// reporting false if they can't be read.
+func AllowedCPUs() (string, bool) {
+	seqs, ok := affinity()
+	return formatSeqs(seqs), ok
+}
+
+// ParseAffinity returns the specification of the CPUs in the passed contents
+// of /proc/self/status, reporting false if there are none.
+func ParseAffinity(status string) (string, bool) {
+	seqs, ok := parseAffinity(status)
+	return formatSeqs(seqs), ok
+}
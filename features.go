@@ -0,0 +1,123 @@
+// Copyright 2020 Brian E. Holland. All rights reserved.
+// The use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package intlist
+
+import (
+	"strconv"
+	"strings"
+)
+
+// FeatureSet is a set of syntax constructs that a specification may use.
+type FeatureSet uint
+
+// The syntax constructs reported by Features.
+const (
+	FeatureRange      FeatureSet = 1 << iota // Sequence (E.g., "1...5")
+	FeatureDescending                        // Decreasing sequence ("5...1")
+	FeatureRoman                             // Roman numeral ("iv" or "XV")
+	FeatureScientific                        // Exponent form ("1.5e3")
+	FeatureArithmetic                        // Constant expression ("2*1024-1")
+	FeatureStep                              // Sequence with a step ("0...100:5")
+	FeatureExclusion                         // Values left out ("1...9,!5")
+	FeatureOpenRange                         // Missing endpoint ("5...") where bounded
+	FeatureRepeat                            // Repeated value ("7x3")
+)
+
+// extensions are the features that must be enabled using WithFeatures.
+// FeatureOpenRange is allowed only where a missing endpoint has a meaning, and
+// the others are part of the base syntax.
+const extensions = FeatureScientific | FeatureStep | FeatureExclusion | FeatureRepeat
+
+// dialects are the syntax extensions giving a meaning to text that is
+// otherwise a typo (E.g., "x" or "1-5"). Unlike the extensions, they are only
+// enabled by WithFeatures, and their literals are syntax errors when not
+// enabled.
+const dialects = FeatureRoman | FeatureArithmetic
+
+// featureNames are the names of the features in bit order.
+var featureNames = []string{"range", "descending", "roman", "scientific",
+	"arithmetic", "step", "exclusion",
+	"open range", "repeat"}
+
+// literals are the ways of writing an integer added by syntax extensions. The
+// parse function reports false if tok is not written that way. Otherwise it
+// returns the value, or the nearest int along with strconv.ErrRange if the
+// value is out of range, like strconv.Atoi.
+var literals = []struct {
+	feature FeatureSet
+	parse   func(tok string) (int, bool, error)
+}{
+	{FeatureRoman, parseRoman},
+	{FeatureScientific, parseScientific},
+	{FeatureArithmetic, parseArithmetic},
+}
+
+// literal parses tok written using a syntax extension, noting the feature as
+// used by c. It reports false if tok is not written using any. The "fn"
+// parameter is the name of the calling function used in any error returned.
+func (c *config) literal(fn, tok string) (int, bool, error) {
+	for _, lit := range literals {
+		v, ok, err := lit.parse(tok)
+		if !ok {
+			continue
+		}
+		if !c.features.Has(lit.feature) {
+			if dialects.Has(lit.feature) {
+				continue
+			}
+			return 0, true, featureError(fn, tok)
+		}
+		c.used |= lit.feature
+		if err != nil {
+			err = &strconv.NumError{Func: fn, Num: tok, Err: err}
+		}
+		return v, true, err
+	}
+	return 0, false, nil
+}
+
+// Has reports whether f holds all of the features in g.
+func (f FeatureSet) Has(g FeatureSet) bool {
+	return f&g == g
+}
+
+// String returns the names of the features in f separated by "|" or "none" if
+// f is empty.
+func (f FeatureSet) String() string {
+	var names []string
+	for i, name := range featureNames {
+		if f&(1<<uint(i)) != 0 {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		return "none"
+	}
+	return strings.Join(names, "|")
+}
+
+// Features returns the syntax constructs used by the passed specification.
+// This lets a gateway reject specifications using constructs that a consumer
+// can't parse. A specification of only single integers uses no features. A
+// missing endpoint is reported as FeatureOpenRange, which only functions
+// giving it a meaning, such as ParseWithBounds, accept.
+//
+//   Features("1,5...9") -> FeatureRange, nil
+//   Features("1,9...5") -> FeatureRange|FeatureDescending, nil
+//   Features("0...100:5") -> FeatureRange|FeatureStep, nil
+//   Features("1e3") -> FeatureScientific, nil
+//   Features("1...9,!5") -> FeatureRange|FeatureExclusion, nil
+//
+// Potential errors returned:
+//
+//   strconv.ErrSyntax - Error parsing integer or sequence notation
+//   strconv.ErrRange - Integer out of range
+func Features(spec string) (FeatureSet, error) {
+	c := &config{features: extensions, open: true, openLo: minInt, openHi: maxInt}
+	if _, err := parseSpec("Features", spec, c); err != nil {
+		return 0, err
+	}
+	return c.used, nil // Constructs of the items as written
+}
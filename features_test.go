@@ -0,0 +1,66 @@
+// Copyright 2020 Brian E. Holland. All rights reserved.
+// The use of this source code is governed by an MIT license
+// that can be found in the LICENSE file.
+
+package intlist_test
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/brianholland99/intlist"
+)
+
+type featuresTest struct {
+	in  string
+	out intlist.FeatureSet
+	err error
+}
+
+var featuresTests = []featuresTest{
+	// Good cases
+	{"1,2,3", 0, nil},                      // None
+	{"1,5...9", intlist.FeatureRange, nil}, // Range
+	{"1,9...5", intlist.FeatureRange | intlist.FeatureDescending, nil},  // Both
+	{"0...10:5", intlist.FeatureRange | intlist.FeatureStep, nil},       // Step
+	{"0...10:1", intlist.FeatureRange | intlist.FeatureStep, nil},       // Unit step
+	{"1...9,!5", intlist.FeatureRange | intlist.FeatureExclusion, nil},  // Exclusion
+	{"5...,...1", intlist.FeatureRange | intlist.FeatureOpenRange, nil}, // Open
+	{"7x3", intlist.FeatureRepeat, nil},                                 // Repetition
+	{"5...5", intlist.FeatureRange, nil},                                // One value
+	{"1...9,!5...3", intlist.FeatureRange | intlist.FeatureDescending |
+		intlist.FeatureExclusion, nil}, // Descending exclusion
+	{"9...1,!2...8", intlist.FeatureRange | intlist.FeatureDescending |
+		intlist.FeatureExclusion, nil}, // Split by an exclusion
+	{"", 0, nil}, // Empty
+	// Error cases
+	{"1..5", 0, strconv.ErrSyntax},  // Bad ellipsis
+	{"i...v", 0, strconv.ErrSyntax}, // Dialect not enabled
+}
+
+func TestFeatures(t *testing.T) {
+	for _, test := range featuresTests {
+		out, err := intlist.Features(test.in)
+		if out != test.out || !errors.Is(err, test.err) {
+			t.Errorf("Features(%q) = (%v), (%v) -- wanted (%v), (%v)",
+				test.in, out, err, test.out, test.err)
+		}
+	}
+}
+
+func TestFeatureSetString(t *testing.T) {
+	f := intlist.FeatureRange | intlist.FeatureDescending
+	if got, want := f.String(), "range|descending"; got != want {
+		t.Errorf("FeatureSet.String() = %q -- wanted %q", got, want)
+	}
+	if got, want := (intlist.FeatureRoman | intlist.FeatureRange).String(), "range|roman"; got != want {
+		t.Errorf("FeatureSet.String() = %q -- wanted %q", got, want)
+	}
+	if got, want := intlist.FeatureSet(0).String(), "none"; got != want {
+		t.Errorf("FeatureSet(0).String() = %q -- wanted %q", got, want)
+	}
+	if !f.Has(intlist.FeatureRange) || intlist.FeatureRange.Has(f) {
+		t.Errorf("FeatureSet.Has gave wrong result for %v", f)
+	}
+}
@@ -0,0 +1,125 @@
+// Copyright 2020 Brian E. Holland. All rights reserved.
+// The use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package intlist
+
+import (
+	"strconv"
+	"strings"
+)
+
+// formatSeqs returns a specification whose expansion is the values of seqs.
+func formatSeqs(seqs []seq) string {
+	var b strings.Builder
+	for i, s := range seqs {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		writeSeq(&b, s)
+	}
+	return b.String()
+}
+
+// Format returns the most compact specification expanding to values, so a
+// list generated programmatically can be shown in the notation people enter.
+// Runs of consecutive values, increasing or decreasing, become sequences,
+// unless listing them is shorter. The result parses using Parse, so copies of
+// a value are listed rather than written as repetitions ("7x3").
+//
+//   Format([]int{1, 2, 3, 4, 9, 7, 6, 5, 10, 11}) -> "1...4,9,7...5,10,11"
+//   Format([]int{0, 0, 1}) -> "0,0,1"
+//   Format(nil) -> ""
+func Format(values []int) string {
+	seqs := make([]seq, 0, len(values))
+	for _, v := range values {
+		seqs = append(seqs, seq{next: v, last: v})
+	}
+	seqs = minifySeqs(seqs)
+	result := make([]seq, 0, len(seqs))
+	for _, s := range seqs {
+		if s.len() == 2 { // "a,b" is shorter than "a...b".
+			result = append(result, seq{next: s.next, last: s.next},
+				seq{next: s.last, last: s.last})
+			continue
+		}
+		result = append(result, s)
+	}
+	return formatSeqs(result)
+}
+
+// writeSeq writes the notation for s to b.
+func writeSeq(b *strings.Builder, s seq) {
+	switch {
+	case s.reps > 0: // Repeated value
+		b.WriteString(strconv.Itoa(s.next))
+		b.WriteByte('x')
+		b.WriteString(strconv.Itoa(s.reps + 1))
+	case s.next == s.last: // Single value
+		b.WriteString(strconv.Itoa(s.next))
+	case s.step == 1 || s.step == -1: // Sequence
+		b.WriteString(strconv.Itoa(s.next))
+		b.WriteString("...")
+		b.WriteString(strconv.Itoa(s.last))
+	default: // Sequence with a step
+		b.WriteString(strconv.Itoa(s.next))
+		b.WriteString("...")
+		b.WriteString(strconv.Itoa(s.last))
+		b.WriteByte(':')
+		b.WriteString(strconv.FormatUint(s.stride(), 10))
+	}
+}
+
+// Pretty returns the passed specification reflowed across multiple lines of
+// at most width characters with the items aligned in columns.
+//
+// Each line except the last ends with a comma, so the result can be parsed
+// using WithLenientWhitespace. This makes programmatically generated
+// specifications easier to edit and diff. A line holds at least one item even
+// if that exceeds width. The items are written from the parsed sequences, so
+// any exclusions are applied.
+//
+//   Pretty("1,20...25,300,4000...4010,5", 25) ->
+//       "1,           20...25,\n300,         4000...4010,\n5", nil
+//
+// Potential errors returned:
+//
+//   strconv.ErrSyntax - Error parsing integer or sequence notation
+//   strconv.ErrRange - Integer out of range
+func Pretty(spec string, width int) (string, error) {
+	seqs, err := parseSeqs("Pretty", spec)
+	if err != nil {
+		return "", err
+	}
+	items := make([]string, len(seqs)) // Notation for each sequence
+	for i, s := range seqs {
+		var b strings.Builder
+		writeSeq(&b, s)
+		items[i] = b.String()
+	}
+	cell := 0 // Width of a column including the comma
+	for _, item := range items {
+		if len(item)+1 > cell {
+			cell = len(item) + 1
+		}
+	}
+	cols := (width + 1) / (cell + 1) // Columns are separated by a space.
+	if cols < 1 {
+		cols = 1
+	}
+	var b strings.Builder
+	for i, item := range items {
+		switch {
+		case i == len(items)-1: // Last item of all has no comma.
+			b.WriteString(item)
+		case (i+1)%cols == 0: // Last item of a line
+			b.WriteString(item)
+			b.WriteString(",\n")
+		default:
+			b.WriteString(item)
+			b.WriteByte(',')
+			b.WriteString(strings.Repeat(" ", cell-len(item)))
+		}
+	}
+	return b.String(), nil
+}
@@ -0,0 +1,101 @@
+// Copyright 2020 Brian E. Holland. All rights reserved.
+// The use of this source code is governed by an MIT license
+// that can be found in the LICENSE file.
+
+package intlist_test
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/brianholland99/intlist"
+	"github.com/google/go-cmp/cmp"
+)
+
+type prettyTest struct {
+	in    string
+	width int
+	out   string
+	err   error
+}
+
+var prettyTests = []prettyTest{
+	// Good cases
+	{"1,20...25,300,4000...4010,5", 25,
+		"1,           20...25,\n300,         4000...4010,\n5", nil}, // Two columns
+	{"1,2,3", 1, "1,\n2,\n3", nil},           // Narrower than an item
+	{"1,2,3", 80, "1, 2, 3", nil},            // Fits on one line
+	{"", 80, "", nil},                        // Empty list
+	{"1...10,!5", 80, "1...4,  6...10", nil}, // Exclusion applied
+	// Error cases
+	{"1,,2", 80, "", strconv.ErrSyntax}, // Empty item
+}
+
+func TestPretty(t *testing.T) {
+	for _, test := range prettyTests {
+		out, err := intlist.Pretty(test.in, test.width)
+		if out != test.out || !errors.Is(err, test.err) {
+			t.Errorf("Pretty(%q, %d) = (%q), (%v) -- wanted (%q), (%v)",
+				test.in, test.width, out, err, test.out, test.err)
+		}
+	}
+}
+
+// The output of Pretty must parse to the same values when leniently parsed.
+func TestPrettyParses(t *testing.T) {
+	spec := "1,20...25,300,4000...4010,5,-6"
+	out, _ := intlist.Pretty(spec, 20)
+	got, err := intlist.ParseWithOptions(out, intlist.WithLenientWhitespace())
+	want, _ := intlist.Parse(spec)
+	if err != nil || !cmp.Equal(got, want) {
+		t.Errorf("ParseWithOptions(Pretty(%q)) = (%v), (%v) -- wanted (%v)",
+			spec, got, err, want)
+	}
+}
+
+type formatTest struct {
+	in  []int
+	out string
+}
+
+var formatTests = []formatTest{
+	{[]int{1, 2, 3, 4, 9, 7, 6, 5, 10, 11}, "1...4,9,7...5,10,11"}, // Runs
+	{[]int{3, 2, 1, 0, -1}, "3...-1"},                              // Decreasing
+	{[]int{5, 5, 6}, "5,5,6"},                                      // Repeat
+	{[]int{0, 0, 0, 0, 1, 2, 3}, "0,0,0,0...3"},                    // Copies listed
+	{[]int{1, 3, 5}, "1,3,5"},                                      // No runs
+	{[]int{7}, "7"},                                                // Single value
+	{nil, ""},                                                      // Empty list
+}
+
+func TestFormat(t *testing.T) {
+	for _, test := range formatTests {
+		if out := intlist.Format(test.in); out != test.out {
+			t.Errorf("Format(%v) = %q -- wanted %q", test.in, out, test.out)
+		}
+	}
+}
+
+// The output of Format must parse to the values formatted.
+func TestFormatParses(t *testing.T) {
+	for _, test := range parseTests {
+		if test.err != nil {
+			continue
+		}
+		checkFormatParses(t, test.out)
+	}
+	for _, test := range formatTests {
+		checkFormatParses(t, test.in)
+	}
+	checkFormatParses(t, []int{7, 7, 7, 7, 7, 8, 8, 8})
+}
+
+// checkFormatParses checks that Parse returns values for the output of Format.
+func checkFormatParses(t *testing.T, values []int) {
+	t.Helper()
+	spec := intlist.Format(values)
+	if got, err := intlist.Parse(spec); err != nil || !cmp.Equal(got, append([]int{}, values...)) {
+		t.Errorf("Parse(Format(%v)) = (%v), (%v) -- wanted (%v), (nil)", values, got, err, values)
+	}
+}
@@ -1,5 +1,5 @@
 module github.com/brianholland99/intlist
 
-go 1.15
+go 1.18
 
 require github.com/google/go-cmp v0.5.2
@@ -0,0 +1,135 @@
+// Copyright 2020 Brian E. Holland. All rights reserved.
+// The use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+// Package intlisttest generates random intlist specifications for property
+// testing code that consumes them.
+//
+// Valid specifications come with their expected expansion so that a property
+// can compare against it. Near-valid specifications are valid ones with a
+// single mutation making them invalid, which is useful to check that errors
+// are handled.
+//
+// Spec and NearValidSpec implement quick.Generator so they can be used as the
+// arguments of properties checked by testing/quick:
+//
+//   f := func(s intlisttest.Spec) bool {
+//       out, err := intlist.Parse(s.Text)
+//       return err == nil && reflect.DeepEqual(out, s.Values)
+//   }
+//   if err := quick.Check(f, nil); err != nil {
+//       t.Error(err)
+//   }
+//
+// The Valid and NearValid functions can be used directly with other property
+// testing libraries.
+package intlisttest
+
+import (
+	"math/rand"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Limits of the integers generated. They are small so that values are often
+// shared between items.
+const (
+	minValue = -1000
+	maxValue = 1000
+	maxSpan  = 20 // Maximum number of values in a generated sequence
+)
+
+// Spec is a valid specification with its expected expansion.
+type Spec struct {
+	Text   string // Specification
+	Values []int  // Values of the expansion of Text
+}
+
+// Generate returns a random Spec with at most size items. It implements
+// quick.Generator.
+func (Spec) Generate(r *rand.Rand, size int) reflect.Value {
+	return reflect.ValueOf(Valid(r, size))
+}
+
+// NearValidSpec is an invalid specification that differs from a valid one by
+// a single mutation.
+type NearValidSpec string
+
+// Generate returns a random NearValidSpec based on a valid specification with
+// at most size items. It implements quick.Generator.
+func (NearValidSpec) Generate(r *rand.Rand, size int) reflect.Value {
+	return reflect.ValueOf(NearValidSpec(NearValid(r, size)))
+}
+
+// Valid returns a random valid specification with at most maxItems items
+// along with its expansion. An empty specification is possible.
+func Valid(r *rand.Rand, maxItems int) Spec {
+	if maxItems < 0 {
+		maxItems = 0
+	}
+	items := make([]string, r.Intn(maxItems+1))
+	values := []int{}
+	for i := range items {
+		first := minValue + r.Intn(maxValue-minValue+1)
+		if r.Intn(2) == 0 { // Single value
+			items[i] = strconv.Itoa(first)
+			values = append(values, first)
+			continue
+		}
+		last := first + r.Intn(2*maxSpan+1) - maxSpan
+		items[i] = strconv.Itoa(first) + "..." + strconv.Itoa(last)
+		step := 1
+		if last < first {
+			step = -1
+		}
+		for v := first; ; v += step {
+			values = append(values, v)
+			if v == last {
+				break
+			}
+		}
+	}
+	return Spec{Text: strings.Join(items, ","), Values: values}
+}
+
+// mutations change a valid specification so that it is no longer valid.
+var mutations = []func(r *rand.Rand, spec string) string{
+	// Trailing comma
+	func(r *rand.Rand, spec string) string { return spec + "," },
+	// Empty item
+	func(r *rand.Rand, spec string) string { return spec + ",,1" },
+	// Whitespace
+	func(r *rand.Rand, spec string) string { return " " + spec },
+	// Non-integer
+	func(r *rand.Rand, spec string) string { return spec + ",1.5" },
+	// Letter inside an integer, other than the 'x' of a repetition
+	func(r *rand.Rand, spec string) string {
+		const letters = "abcdefghijklmnopqrstuvwyz"
+		i := strings.IndexAny(spec, "0123456789")
+		return spec[:i+1] + string(letters[r.Intn(len(letters))]) + spec[i+1:]
+	},
+	// Short ellipsis
+	func(r *rand.Rand, spec string) string {
+		if !strings.Contains(spec, "...") {
+			return spec + ",1..2"
+		}
+		return strings.Replace(spec, "...", "..", 1)
+	},
+	// Multiple ellipses in one item
+	func(r *rand.Rand, spec string) string { return spec + ",1...2...3" },
+}
+
+// NearValid returns a random invalid specification made by applying one
+// mutation to a valid specification with at most maxItems items (and at least
+// one).
+func NearValid(r *rand.Rand, maxItems int) string {
+	if maxItems < 1 {
+		maxItems = 1
+	}
+	spec := ""
+	for spec == "" {
+		spec = Valid(r, maxItems).Text
+	}
+	return mutations[r.Intn(len(mutations))](r, spec)
+}
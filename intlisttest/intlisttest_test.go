@@ -0,0 +1,34 @@
+// Copyright 2020 Brian E. Holland. All rights reserved.
+// The use of this source code is governed by an MIT license
+// that can be found in the LICENSE file.
+
+package intlisttest_test
+
+import (
+	"testing"
+	"testing/quick"
+
+	"github.com/brianholland99/intlist"
+	"github.com/brianholland99/intlist/intlisttest"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestValid(t *testing.T) {
+	f := func(s intlisttest.Spec) bool {
+		out, err := intlist.Parse(s.Text)
+		return err == nil && cmp.Equal(out, s.Values)
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestNearValid(t *testing.T) {
+	f := func(s intlisttest.NearValidSpec) bool {
+		_, err := intlist.Parse(string(s))
+		return err != nil
+	}
+	if err := quick.Check(f, &quick.Config{MaxCount: 1000}); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,191 @@
+// Copyright 2020 Brian E. Holland. All rights reserved.
+// The use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package intlist
+
+import (
+	"net"
+	"strconv"
+	"strings"
+)
+
+// MaxAddresses is the limit on the number of addresses that ParseIPv4
+// returns, which keeps a short specification such as "0.0.0.0...255.255.255.255"
+// from exhausting memory.
+const MaxAddresses = 1 << 20
+
+// ParseIPv4 returns the IPv4 addresses represented by the passed
+// specification, for inventory and scanning tools.
+//
+// The specification is a comma-separated list of items. An item is a single
+// address, a sequence of addresses given by two addresses separated by an
+// ellipsis, or an address where any of the four parts is a specification of
+// parts in brackets. Bracketed parts are expanded with the rightmost varying
+// fastest. The order of the specification is kept along with any repeats.
+//
+//   ParseIPv4("192.168.1.254...192.168.2.1") ->
+//       [192.168.1.254 192.168.1.255 192.168.2.0 192.168.2.1], nil
+//   ParseIPv4("10.[1,2].[5...4].0") -> [10.1.5.0 10.1.4.0 10.2.5.0 10.2.4.0], nil
+//
+// Potential errors returned:
+//
+//   strconv.ErrSyntax - Error parsing an address or sequence notation
+//   strconv.ErrRange - Integer out of range
+//   ErrOutOfBounds - A part of an address outside the range 0 through 255
+//   ErrLimit - More than MaxAddresses addresses
+func ParseIPv4(spec string) ([]net.IP, error) {
+	const fn = "ParseIPv4"
+	result := []net.IP{}
+	if spec == "" {
+		return result, nil
+	}
+	for _, item := range splitOutside(spec, ',') {
+		var addrs []uint32
+		var err error
+		if strings.Contains(item, "[") {
+			addrs, err = expandParts(fn, item)
+		} else {
+			addrs, err = addressRange(fn, item)
+		}
+		if err != nil {
+			return nil, err
+		}
+		if len(result)+len(addrs) > MaxAddresses {
+			return nil, &strconv.NumError{Func: fn, Num: item, Err: ErrLimit}
+		}
+		for _, a := range addrs {
+			result = append(result, net.IPv4(byte(a>>24), byte(a>>16), byte(a>>8), byte(a)))
+		}
+	}
+	return result, nil
+}
+
+// splitOutside splits s at each sep that is not inside brackets.
+func splitOutside(s string, sep byte) []string {
+	var parts []string
+	depth, start := 0, 0 // Bracket nesting and start of the current part
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '[':
+			depth++
+		case ']':
+			depth--
+		case sep:
+			if depth == 0 {
+				parts = append(parts, s[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(parts, s[start:])
+}
+
+// addressRange returns the addresses of item, which is an address or two
+// addresses separated by an ellipsis. The "fn" parameter is the name of the
+// calling function used in any error returned.
+func addressRange(fn, item string) ([]uint32, error) {
+	ends := strings.Split(item, "...")
+	if len(ends) > 2 {
+		return nil, &strconv.NumError{Func: fn, Num: item, Err: strconv.ErrSyntax}
+	}
+	var bounds [2]uint32 // First and last addresses
+	for i, end := range ends {
+		a, err := address(fn, item, end)
+		if err != nil {
+			return nil, err
+		}
+		bounds[i] = a
+	}
+	first, last := bounds[0], bounds[len(ends)-1]
+	n := uint64(last) - uint64(first) + 1 // Number of addresses, if increasing
+	if first > last {
+		n = uint64(first) - uint64(last) + 1
+	}
+	if n > MaxAddresses {
+		return nil, &strconv.NumError{Func: fn, Num: item, Err: ErrLimit}
+	}
+	result := make([]uint32, 0, n)
+	for a := first; ; {
+		result = append(result, a)
+		if a == last {
+			return result, nil
+		}
+		if first < last {
+			a++
+		} else {
+			a--
+		}
+	}
+}
+
+// address parses a dotted-quad address from item. The "fn" parameter is the
+// name of the calling function used in any error returned.
+func address(fn, item, text string) (uint32, error) {
+	parts := strings.Split(text, ".")
+	if len(parts) != 4 {
+		return 0, &strconv.NumError{Func: fn, Num: item, Err: strconv.ErrSyntax}
+	}
+	var a uint32
+	for _, part := range parts {
+		v, err := addressPart(fn, item, part)
+		if err != nil {
+			return 0, err
+		}
+		a = a<<8 | uint32(v)
+	}
+	return a, nil
+}
+
+// addressPart parses a decimal part of an address from item. The "fn"
+// parameter is the name of the calling function used in any error returned.
+func addressPart(fn, item, part string) (int, error) {
+	if part == "" || strings.Trim(part, "0123456789") != "" {
+		return 0, &strconv.NumError{Func: fn, Num: item, Err: strconv.ErrSyntax}
+	}
+	v, err := strconv.Atoi(part)
+	if err != nil || v > 255 {
+		return 0, &strconv.NumError{Func: fn, Num: item, Err: ErrOutOfBounds}
+	}
+	return v, nil
+}
+
+// expandParts returns the addresses of item, which is an address with any of
+// its parts a specification in brackets. The "fn" parameter is the name of the
+// calling function used in any error returned.
+func expandParts(fn, item string) ([]uint32, error) {
+	parts := splitOutside(item, '.')
+	if len(parts) != 4 {
+		return nil, &strconv.NumError{Func: fn, Num: item, Err: strconv.ErrSyntax}
+	}
+	result := []uint32{0}
+	for _, part := range parts {
+		var values []int // Values of the part
+		if strings.HasPrefix(part, "[") && strings.HasSuffix(part, "]") {
+			c := &config{features: extensions, bounded: true, lo: 0, hi: 255,
+				maxValues: MaxAddresses}
+			seqs, err := parseSpec(fn, part[1:len(part)-1], c)
+			if err != nil {
+				return nil, err
+			}
+			values = (&Iterator{seqs: seqs}).rest()
+		} else {
+			v, err := addressPart(fn, item, part)
+			if err != nil {
+				return nil, err
+			}
+			values = []int{v}
+		}
+		if len(result)*len(values) > MaxAddresses {
+			return nil, &strconv.NumError{Func: fn, Num: item, Err: ErrLimit}
+		}
+		next := make([]uint32, 0, len(result)*len(values))
+		for _, a := range result {
+			for _, v := range values {
+				next = append(next, a<<8|uint32(v))
+			}
+		}
+		result = next
+	}
+	return result, nil
+}
@@ -0,0 +1,59 @@
+// Copyright 2020 Brian E. Holland. All rights reserved.
+// The use of this source code is governed by an MIT license
+// that can be found in the LICENSE file.
+
+package intlist_test
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/brianholland99/intlist"
+	"github.com/google/go-cmp/cmp"
+)
+
+var parseIPv4Tests = []struct {
+	in  string
+	out []string // Addresses in dotted-quad form
+	err error
+}{
+	// Good cases
+	{"192.168.1.254...192.168.2.1",
+		[]string{"192.168.1.254", "192.168.1.255", "192.168.2.0", "192.168.2.1"}, nil},
+	{"10.[1,2].[5...4].0",
+		[]string{"10.1.5.0", "10.1.4.0", "10.2.5.0", "10.2.4.0"}, nil},
+	{"10.0.0.3...10.0.0.1,8.8.8.8",
+		[]string{"10.0.0.3", "10.0.0.2", "10.0.0.1", "8.8.8.8"}, nil}, // Decreasing
+	{"1.2.3.4,1.2.3.4", []string{"1.2.3.4", "1.2.3.4"}, nil}, // Repeats kept
+	{"255.255.255.254...255.255.255.255",
+		[]string{"255.255.255.254", "255.255.255.255"}, nil},
+	{"", []string{}, nil}, // Empty list
+	// Error cases
+	{"10.0.0", nil, strconv.ErrSyntax},
+	{"10.0.0.-1", nil, strconv.ErrSyntax},
+	{"10.0.0.1..10.0.0.2", nil, strconv.ErrSyntax},
+	{"10.0.[1...2.0", nil, strconv.ErrSyntax},
+	{"10.0.[1..2].0", nil, strconv.ErrSyntax},
+	{"10.0.0.256", nil, intlist.ErrOutOfBounds},
+	{"10.0.[250...256].0", nil, intlist.ErrOutOfBounds},
+	{"0.0.0.0...255.255.255.255", nil, intlist.ErrLimit},
+	{"10.[0...255].[0...255].[0...255]", nil, intlist.ErrLimit},
+}
+
+func TestParseIPv4(t *testing.T) {
+	for _, test := range parseIPv4Tests {
+		addrs, err := intlist.ParseIPv4(test.in)
+		var out []string
+		if addrs != nil {
+			out = []string{}
+		}
+		for _, a := range addrs {
+			out = append(out, a.String())
+		}
+		if !cmp.Equal(out, test.out) || !errors.Is(err, test.err) {
+			t.Errorf("ParseIPv4(%q) = (%v), (%v) -- wanted (%v), (%v)",
+				test.in, out, err, test.out, test.err)
+		}
+	}
+}
@@ -0,0 +1,172 @@
+// Copyright 2020 Brian E. Holland. All rights reserved.
+// The use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package intlist
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// FilterLines copies the lines of r selected by the passed specification to w.
+//
+// Lines are numbered from 1 and are written in the order of the specification
+// along with any repeats, each ending with a newline. Selected lines past the
+// end of r are skipped, as with sed(1). Reading stops after the last selected
+// line. When the specification is strictly increasing lines are copied as they
+// are read; otherwise the selected lines are held in memory until the end.
+//
+//   FilterLines(strings.NewReader("a\nb\nc\n"), w, "3,1...2,2") ->
+//       "c\na\nb\nb\n" written to w, nil
+//
+// Potential errors returned:
+//
+//   strconv.ErrSyntax - Error parsing integer or sequence notation
+//   strconv.ErrRange - Integer out of range
+//   ErrOutOfBounds - Line number less than 1
+//   Errors reading r or writing to w
+func FilterLines(r io.Reader, w io.Writer, spec string) error {
+	seqs, err := parseSpec("FilterLines", spec, &config{
+		features: extensions,
+		bounded:  true,
+		lo:       1,
+		hi:       maxInt,
+	})
+	if err != nil {
+		return err
+	}
+	if len(seqs) == 0 {
+		return nil
+	}
+	spans := normalize(seqSpans(seqs))
+	last := spans[len(spans)-1].hi // Last line to read
+	inOrder := true                // Whether the expansion is strictly increasing
+	for i, s := range seqs {
+		if s.step < 0 || s.reps > 0 || (i > 0 && s.next <= seqs[i-1].last) {
+			inOrder = false
+		}
+	}
+	held := map[int]string{} // Selected lines if not inOrder
+	br := bufio.NewReader(r)
+	bw := bufio.NewWriter(w)
+	for n := 1; n <= last; n++ {
+		line, err := br.ReadString('\n')
+		if err == io.EOF && line == "" {
+			break
+		} else if err != nil && err != io.EOF {
+			return err
+		}
+		for spans[0].hi < n {
+			spans = spans[1:]
+		}
+		if spans[0].contains(n) {
+			line = strings.TrimSuffix(line, "\n")
+			if inOrder {
+				bw.WriteString(line)
+				bw.WriteByte('\n')
+			} else {
+				held[n] = line
+			}
+		}
+		if err == io.EOF {
+			break
+		}
+	}
+	if !inOrder {
+		it := &Iterator{seqs: seqs}
+		for {
+			n, err := it.Next()
+			if err == ErrDone {
+				break
+			}
+			if line, ok := held[n]; ok {
+				bw.WriteString(line)
+				bw.WriteByte('\n')
+			}
+		}
+	}
+	return bw.Flush()
+}
+
+// CutFields copies the fields of each line of r selected by the passed
+// specification to w, like cut(1) with the -f flag.
+//
+// Fields are separated by delim and numbered from 1. They are written in the
+// order of the specification along with any repeats, separated by delim, with
+// each line ending with a newline. Selected fields past the end of a line are
+// skipped, so a line without delim is treated as a single field.
+//
+//   CutFields(strings.NewReader("a:b:c\nd:e\n"), w, "3,1...2", ":") ->
+//       "c:a:b\nd:e\n" written to w, nil
+//
+// Potential errors returned:
+//
+//   strconv.ErrSyntax - Error parsing integer or sequence notation
+//   strconv.ErrRange - Integer out of range
+//   ErrOutOfBounds - Field number less than 1
+//   ErrInvalidArg - The "delim" parameter is empty
+//   Errors reading r or writing to w
+func CutFields(r io.Reader, w io.Writer, spec, delim string) error {
+	if delim == "" {
+		return fmt.Errorf("intlist.CutFields: empty delimiter: %w", ErrInvalidArg)
+	}
+	seqs, err := parseSpec("CutFields", spec, &config{
+		features: extensions,
+		bounded:  true,
+		lo:       1,
+		hi:       maxInt,
+	})
+	if err != nil {
+		return err
+	}
+	br := bufio.NewReader(r)
+	bw := bufio.NewWriter(w)
+	for {
+		line, err := br.ReadString('\n')
+		if err == io.EOF && line == "" {
+			break
+		} else if err != nil && err != io.EOF {
+			return err
+		}
+		fields := strings.Split(strings.TrimSuffix(line, "\n"), delim)
+		wrote := false // Whether a field was written for this line
+		for _, s := range seqs {
+			s, ok := s.clip(len(fields))
+			if !ok {
+				continue
+			}
+			for k, n := uint64(0), s.len(); k < n; k++ {
+				if wrote {
+					bw.WriteString(delim)
+				}
+				bw.WriteString(fields[s.at(k)-1])
+				wrote = true
+			}
+		}
+		bw.WriteByte('\n')
+		if err == io.EOF {
+			break
+		}
+	}
+	return bw.Flush()
+}
+
+// clip returns the part of s with values not greater than n, keeping the order
+// of s. It reports false if no values remain.
+func (s seq) clip(n int) (seq, bool) {
+	lo, hi := s.bounds()
+	switch {
+	case lo > n:
+		return s, false
+	case hi <= n:
+		return s, true
+	case s.step > 0:
+		s.last -= ((s.last-n-1)/s.step + 1) * s.step
+	default: // Decreasing since a single value is within the bounds
+		s.next += ((s.next-n-1)/-s.step + 1) * s.step
+	}
+	return s, true
+}
@@ -0,0 +1,93 @@
+// Copyright 2020 Brian E. Holland. All rights reserved.
+// The use of this source code is governed by an MIT license
+// that can be found in the LICENSE file.
+
+package intlist_test
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/brianholland99/intlist"
+)
+
+var filterLinesTests = []struct {
+	text string
+	spec string
+	out  string
+	err  error
+}{
+	// Good cases
+	{"a\nb\nc\nd\n", "2...3", "b\nc\n", nil},                                         // Streamed
+	{"a\nb\nc\n", "3,1...2,2", "c\na\nb\nb\n", nil},                                  // Held
+	{"a\nb\nc", "3...1", "c\nb\na\n", nil},                                           // No final newline
+	{"a\nb\n", "2...5,9", "b\n", nil},                                                // Past the end
+	{"a\r\nb\r\n", "2", "b\r\n", nil},                                                // Carriage return kept
+	{"a\n\nc\n", "1...3", "a\n\nc\n", nil},                                           // Blank line
+	{"a\nb\n", "", "", nil},                                                          // No lines
+	{"a\nb\nc\nd\ne\n", "1...1000000000:2", "a\nc\ne\n", nil},                        // Steps
+	{"", "1", "", nil},                                                               // No input
+	{strings.Repeat("x", 1<<17) + "\n", "1", strings.Repeat("x", 1<<17) + "\n", nil}, // Long line
+	// Error cases
+	{"a\n", "0", "", intlist.ErrOutOfBounds},
+	{"a\n", "1..2", "", strconv.ErrSyntax},
+}
+
+func TestFilterLines(t *testing.T) {
+	for _, test := range filterLinesTests {
+		var b strings.Builder
+		err := intlist.FilterLines(strings.NewReader(test.text), &b, test.spec)
+		if b.String() != test.out || !errors.Is(err, test.err) {
+			t.Errorf("FilterLines(%.20q, %q) wrote (%.20q), (%v) -- wanted (%.20q), (%v)",
+				test.text, test.spec, b.String(), err, test.out, test.err)
+		}
+	}
+}
+
+// Reading stops after the last selected line.
+func TestFilterLinesStops(t *testing.T) {
+	r := strings.NewReader("a\nb\n" + strings.Repeat("x\n", 1<<16))
+	var b strings.Builder
+	if err := intlist.FilterLines(r, &b, "2"); err != nil || b.String() != "b\n" {
+		t.Fatalf("FilterLines(r, %q) wrote (%q), (%v) -- wanted (%q), (nil)",
+			"2", b.String(), err, "b\n")
+	}
+	if r.Len() == 0 {
+		t.Errorf("FilterLines(r, %q) read all of r -- wanted it to stop", "2")
+	}
+}
+
+var cutFieldsTests = []struct {
+	text  string
+	spec  string
+	delim string
+	out   string
+	err   error
+}{
+	// Good cases
+	{"a:b:c\nd:e\n", "3,1...2", ":", "c:a:b\nd:e\n", nil}, // Reordered
+	{"a:b:c\n", "3...1,1", ":", "c:b:a:a\n", nil},         // Repeats
+	{"a:b:c:d:e\n", "5...2", ":", "e:d:c:b\n", nil},       // Decreasing
+	{"a:b\n\nc\n", "2...9", ":", "b\n\n\n", nil},          // Past the end
+	{"a:b\nc:d", "9...1", ":", "b:a\nd:c\n", nil},         // Clipped start
+	{"a, b, c\n", "1,3", ", ", "a, c\n", nil},             // Longer delimiter
+	{"a\tb\n", "", "\t", "\n", nil},                       // No fields
+	{"", "1", ":", "", nil},                               // No input
+	// Error cases
+	{"a:b\n", "0", ":", "", intlist.ErrOutOfBounds},
+	{"a:b\n", "1..2", ":", "", strconv.ErrSyntax},
+	{"a:b\n", "1", "", "", intlist.ErrInvalidArg},
+}
+
+func TestCutFields(t *testing.T) {
+	for _, test := range cutFieldsTests {
+		var b strings.Builder
+		err := intlist.CutFields(strings.NewReader(test.text), &b, test.spec, test.delim)
+		if b.String() != test.out || !errors.Is(err, test.err) {
+			t.Errorf("CutFields(%q, %q, %q) wrote (%q), (%v) -- wanted (%q), (%v)",
+				test.text, test.spec, test.delim, b.String(), err, test.out, test.err)
+		}
+	}
+}
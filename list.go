@@ -0,0 +1,121 @@
+// Copyright 2020 Brian E. Holland. All rights reserved.
+// The use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package intlist
+
+// List is a parsed specification. It holds the sequences rather than the
+// values, so it is small even when the expansion is huge. A List can be
+// iterated any number of times.
+//
+// A List is never changed once created, so copies of it may be shared freely,
+// such as in configuration structs. Lists are compared with Equal, which
+// go-cmp also uses.
+type List struct {
+	seqs []seq // Sequences of the specification
+}
+
+// Compile parses the specification into a List using any options.
+//
+//   Compile("1...3,10") -> &List{...} with values [1 2 3 10], nil
+//
+// Potential errors returned are the same as for ParseWithOptions.
+func Compile(spec string, opts ...Option) (*List, error) {
+	seqs, err := parseSpec("Compile", spec, newConfig(opts))
+	if err != nil {
+		return nil, err
+	}
+	return &List{seqs: seqs}, nil
+}
+
+// Iterator returns a new Iterator over the values of l.
+func (l *List) Iterator() *Iterator {
+	seqs := make([]seq, len(l.seqs)) // Iterator changes the sequences.
+	copy(seqs, l.seqs)
+	return &Iterator{seqs: seqs}
+}
+
+// Ints returns the values of l as an int slice.
+func (l *List) Ints() []int {
+	return l.Iterator().rest()
+}
+
+// String returns a specification for the values of l.
+func (l *List) String() string {
+	return formatSeqs(l.seqs)
+}
+
+// Contains reports whether v is a value of l without expanding it.
+//
+//   l, _ := Compile("1...10000000")
+//   l.Contains(4096) -> true
+func (l *List) Contains(v int) bool {
+	return containsValue(l.sequences(), v)
+}
+
+// Union returns a List of the values in l or other in increasing order without
+// duplicates. See Union for specifications.
+func (l *List) Union(other *List) *List {
+	return &List{seqs: setSeqs(l.sequences(), other.sequences(), unionSpans)}
+}
+
+// Intersect returns a List of the values in both l and other in increasing
+// order without duplicates. See Intersect for specifications.
+func (l *List) Intersect(other *List) *List {
+	return &List{seqs: setSeqs(l.sequences(), other.sequences(), intersectSpans)}
+}
+
+// Subtract returns a List of the values in l but not in other in increasing
+// order without duplicates. See Subtract for specifications.
+func (l *List) Subtract(other *List) *List {
+	return &List{seqs: setSeqs(l.sequences(), other.sequences(), differenceSpans)}
+}
+
+// Equal reports whether l and other expand to the same values in the same
+// order, however they were written. Sequences are compared without being
+// expanded. A nil List is equal to an empty one.
+//
+//   a, _ := Compile("1...3,4")
+//   b, _ := Compile("1,2,3...4")
+//   a.Equal(b) -> true
+func (l *List) Equal(other *List) bool {
+	a, b := l.sequences(), other.sequences()
+	var ka, kb uint64 // Positions in a[0] and b[0]
+	for len(a) > 0 && len(b) > 0 {
+		if a[0].at(ka) != b[0].at(kb) {
+			return false
+		}
+		// Values after the current ones left in a[0] and b[0]
+		moreA, moreB := a[0].len()-1-ka, b[0].len()-1-kb
+		if moreA > 0 && moreB > 0 && a[0].step != b[0].step {
+			return false
+		}
+		same := moreA // How many values after the current ones match
+		if moreB < same {
+			same = moreB
+		}
+		ka, kb = ka+same+1, kb+same+1
+		if same == moreA {
+			a, ka = a[1:], 0
+		}
+		if same == moreB {
+			b, kb = b[1:], 0
+		}
+	}
+	return len(a) == 0 && len(b) == 0
+}
+
+// Clone returns a copy of l sharing nothing with it.
+func (l *List) Clone() *List {
+	seqs := make([]seq, len(l.seqs))
+	copy(seqs, l.seqs)
+	return &List{seqs: seqs}
+}
+
+// sequences returns the sequences of l, which may be nil.
+func (l *List) sequences() []seq {
+	if l == nil {
+		return nil
+	}
+	return l.seqs
+}
@@ -0,0 +1,103 @@
+// Copyright 2020 Brian E. Holland. All rights reserved.
+// The use of this source code is governed by an MIT license
+// that can be found in the LICENSE file.
+
+package intlist_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/brianholland99/intlist"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestCompile(t *testing.T) {
+	for _, test := range parseTests {
+		list, err := intlist.Compile(test.in)
+		if !errors.Is(err, test.err) {
+			t.Errorf("Compile(%q) error = (%v) -- wanted (%v)",
+				test.in, err, test.err)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+		// Iterating twice must give the same values.
+		for pass := 1; pass <= 2; pass++ {
+			if out := list.Ints(); !cmp.Equal(out, test.out) {
+				t.Errorf("Compile(%q).Ints() pass %d = %v -- wanted %v",
+					test.in, pass, out, test.out)
+			}
+		}
+	}
+}
+
+func TestListString(t *testing.T) {
+	list, err := intlist.Compile("1, 5...3 ,9", intlist.WithLenientWhitespace())
+	if err != nil {
+		t.Fatalf("Compile error = %v", err)
+	}
+	if got, want := list.String(), "1,5...3,9"; got != want {
+		t.Errorf("List.String() = %q -- wanted %q", got, want)
+	}
+}
+
+var listEqualTests = []struct {
+	a, b string
+	out  bool
+}{
+	{"1...3,4", "1,2,3...4", true}, // Written differently
+	{"1,2,1", "1...2,1", true},     // Direction change
+	{"5...1", "5,4,3...1", true},   // Decreasing
+	{"1...3", "1...3,3", false},    // Extra value
+	{"1...3", "3...1", false},      // Order matters
+	{"1,3", "1...3", false},        // Missing value
+	{"7", "7", true},               // Single value
+	{"", "", true},                 // Empty lists
+	{"", "1", false},               // Empty and not
+	{"1...10,20", "1...5,6...10,20", true},
+}
+
+func TestListEqual(t *testing.T) {
+	for _, test := range listEqualTests {
+		a, err := intlist.Compile(test.a)
+		if err != nil {
+			t.Fatalf("Compile(%q) error = %v", test.a, err)
+		}
+		b, err := intlist.Compile(test.b)
+		if err != nil {
+			t.Fatalf("Compile(%q) error = %v", test.b, err)
+		}
+		if out := a.Equal(b); out != test.out {
+			t.Errorf("Equal(%q, %q) = %v -- wanted %v", test.a, test.b, out, test.out)
+		}
+		if out := b.Equal(a); out != test.out {
+			t.Errorf("Equal(%q, %q) = %v -- wanted %v", test.b, test.a, out, test.out)
+		}
+		// go-cmp uses the Equal method.
+		if out := cmp.Equal(a, b); out != test.out {
+			t.Errorf("cmp.Equal(%q, %q) = %v -- wanted %v", test.a, test.b, out, test.out)
+		}
+	}
+}
+
+func TestListClone(t *testing.T) {
+	list, err := intlist.Compile("1...3,9")
+	if err != nil {
+		t.Fatalf("Compile error = %v", err)
+	}
+	clone := list.Clone()
+	if !clone.Equal(list) || clone.String() != "1...3,9" {
+		t.Errorf("Clone() = %v -- wanted %v", clone, list)
+	}
+	// Iterating the clone does not use up the original.
+	_ = clone.Ints()
+	if got, want := list.Ints(), []int{1, 2, 3, 9}; !cmp.Equal(got, want) {
+		t.Errorf("Ints() after cloning = %v -- wanted %v", got, want)
+	}
+	var none *intlist.List
+	if empty, _ := intlist.Compile(""); !none.Equal(empty) || !empty.Equal(none) {
+		t.Errorf("nil List not equal to an empty List")
+	}
+}
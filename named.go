@@ -0,0 +1,125 @@
+// Copyright 2020 Brian E. Holland. All rights reserved.
+// The use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package intlist
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"strings"
+)
+
+// ErrConfigFile is returned when a file of named specifications is not well
+// formed, such as a line without a name or a name defined twice.
+var ErrConfigFile = errors.New("invalid named specification file")
+
+// LoadNamed reads the file called name from fsys holding one named
+// specification per line and returns the parsed Lists by name. The "opts"
+// parameter is applied to the parsing of each specification.
+//
+// Each line is "name: spec", a comment starting with "#", blank, or
+// "include file" to read the named specifications of another file, whose
+// name is relative to the directory of the including file. A "#" also starts
+// a comment after a specification. Names may not hold whitespace or be
+// defined more than once.
+//
+//   # Shared by every host.
+//   include common.conf
+//   workers: 1...8   # One per core
+//   reserved: 0,9
+//
+//   LoadNamed(os.DirFS("/etc/app"), "ids.conf") ->
+//       map[reserved:0,9 workers:1...8 ...], nil
+//
+// Errors returned hold the file name and line (origin 1) and wrap any error
+// from reading or parsing. Potential errors returned:
+//
+//   ErrConfigFile - Malformed line, duplicate name or circular include
+//   Errors of fs.ReadFile - Reading a file failed
+//   Errors of ParseWithOptions - Parsing a specification failed
+func LoadNamed(fsys fs.FS, name string, opts ...Option) (map[string]*List, error) {
+	l := &namedLoader{
+		fsys:   fsys,
+		c:      newConfig(opts),
+		lists:  map[string]*List{},
+		active: map[string]bool{},
+	}
+	if err := l.load(name); err != nil {
+		return nil, fmt.Errorf("intlist.LoadNamed: %w", err)
+	}
+	return l.lists, nil
+}
+
+// namedLoader is the state of a LoadNamed call.
+type namedLoader struct {
+	fsys   fs.FS            // Source of files
+	c      *config          // Configuration for parsing each specification
+	lists  map[string]*List // Lists loaded so far by name
+	active map[string]bool  // Files being loaded, to catch circular includes
+}
+
+// load adds the named specifications of the file called name and of any
+// files it includes. Errors returned are preceded by the file name and line,
+// so those of included files show the chain of includes.
+func (l *namedLoader) load(name string) error {
+	data, err := fs.ReadFile(l.fsys, name)
+	if err != nil {
+		return err
+	}
+	l.active[name] = true
+	defer delete(l.active, name)
+
+	sc := bufio.NewScanner(bytes.NewReader(data))
+	for line := 1; sc.Scan(); line++ {
+		text := sc.Text()
+		if i := strings.IndexByte(text, '#'); i >= 0 {
+			text = text[:i]
+		}
+		text = strings.TrimSpace(text)
+		if text == "" {
+			continue
+		}
+		if err := l.define(name, text); err != nil {
+			return fmt.Errorf("%s:%d: %w", name, line, err)
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return fmt.Errorf("%s: %w", name, err)
+	}
+	return nil
+}
+
+// define handles the non-blank, uncommented text of a line of the file
+// called name.
+func (l *namedLoader) define(name, text string) error {
+	key, spec, found := strings.Cut(text, ":")
+	if !found {
+		fields := strings.Fields(text)
+		if len(fields) != 2 || fields[0] != "include" {
+			return fmt.Errorf("%q: %w", text, ErrConfigFile)
+		}
+		file := path.Join(path.Dir(name), fields[1])
+		if l.active[file] {
+			return fmt.Errorf("circular include of %q: %w", file, ErrConfigFile)
+		}
+		return l.load(file)
+	}
+	key = strings.TrimSpace(key)
+	if key == "" || strings.ContainsAny(key, " \t") {
+		return fmt.Errorf("name %q: %w", key, ErrConfigFile)
+	}
+	if _, ok := l.lists[key]; ok {
+		return fmt.Errorf("name %q defined again: %w", key, ErrConfigFile)
+	}
+	seqs, err := parseSpec("LoadNamed", strings.TrimSpace(spec), l.c)
+	if err != nil {
+		return err
+	}
+	l.lists[key] = &List{seqs: seqs}
+	return nil
+}
@@ -0,0 +1,84 @@
+// Copyright 2020 Brian E. Holland. All rights reserved.
+// The use of this source code is governed by an MIT license
+// that can be found in the LICENSE file.
+
+package intlist_test
+
+import (
+	"errors"
+	"io/fs"
+	"strconv"
+	"testing"
+	"testing/fstest"
+
+	"github.com/brianholland99/intlist"
+	"github.com/google/go-cmp/cmp"
+)
+
+var namedFiles = fstest.MapFS{
+	"etc/ids.conf": {Data: []byte("# Shared by every host.\ninclude common.conf\n" +
+		"workers: 1...8   # One per core\n\n  reserved : 0,9\nnone:\n")},
+	"etc/common.conf":    {Data: []byte("include sub/admin.conf\nguests: 100...90\n")},
+	"etc/sub/admin.conf": {Data: []byte("admin: 1\n")},
+	"bad/syntax.conf":    {Data: []byte("a: 1\nb: 1..3\n")},
+	"bad/line.conf":      {Data: []byte("a: 1\njust words\n")},
+	"bad/name.conf":      {Data: []byte("two words: 1\n")},
+	"bad/again.conf":     {Data: []byte("a: 1\ninclude again2.conf\n")},
+	"bad/again2.conf":    {Data: []byte("a: 2\n")},
+	"bad/loop.conf":      {Data: []byte("include loop2.conf\n")},
+	"bad/loop2.conf":     {Data: []byte("include loop.conf\n")},
+	"bad/missing.conf":   {Data: []byte("include nowhere.conf\n")},
+}
+
+var loadNamedTests = []struct {
+	in  string
+	out map[string][]int
+	err error
+}{
+	// Good cases
+	{"etc/ids.conf", map[string][]int{
+		"admin":    {1},
+		"guests":   {100, 99, 98, 97, 96, 95, 94, 93, 92, 91, 90},
+		"workers":  {1, 2, 3, 4, 5, 6, 7, 8},
+		"reserved": {0, 9},
+		"none":     {},
+	}, nil},
+	{"etc/sub/admin.conf", map[string][]int{"admin": {1}}, nil},
+	// Error cases
+	{"bad/syntax.conf", nil, strconv.ErrSyntax},
+	{"bad/line.conf", nil, intlist.ErrConfigFile},
+	{"bad/name.conf", nil, intlist.ErrConfigFile},
+	{"bad/again.conf", nil, intlist.ErrConfigFile},
+	{"bad/loop.conf", nil, intlist.ErrConfigFile},
+	{"bad/missing.conf", nil, fs.ErrNotExist},
+	{"nowhere.conf", nil, fs.ErrNotExist},
+}
+
+func TestLoadNamed(t *testing.T) {
+	for _, test := range loadNamedTests {
+		lists, err := intlist.LoadNamed(namedFiles, test.in)
+		if !errors.Is(err, test.err) {
+			t.Errorf("LoadNamed(%q) error = (%v) -- wanted (%v)", test.in, err, test.err)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+		out := map[string][]int{}
+		for name, list := range lists {
+			out[name] = list.Ints()
+		}
+		if !cmp.Equal(out, test.out) {
+			t.Errorf("LoadNamed(%q) = %v -- wanted %v", test.in, out, test.out)
+		}
+	}
+}
+
+func TestLoadNamedPosition(t *testing.T) {
+	_, err := intlist.LoadNamed(namedFiles, "bad/again.conf")
+	if got, want := err.Error(),
+		`intlist.LoadNamed: bad/again.conf:2: bad/again2.conf:1: `+
+			`name "a" defined again: invalid named specification file`; got != want {
+		t.Errorf("LoadNamed error = %q -- wanted %q", got, want)
+	}
+}
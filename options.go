@@ -0,0 +1,928 @@
+// Copyright 2020 Brian E. Holland. All rights reserved.
+// The use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package intlist
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// ErrEmpty is returned when an empty specification is not allowed.
+var ErrEmpty = errors.New("empty specification")
+
+// ErrDescending is returned when a decreasing sequence is not allowed.
+var ErrDescending = errors.New("decreasing sequence")
+
+// ErrDuplicate is returned when a value appears more than once and duplicates
+// are not allowed.
+var ErrDuplicate = errors.New("duplicate value")
+
+// ErrOutOfBounds is returned when a value is outside of the bounds set by an
+// Option.
+var ErrOutOfBounds = errors.New("value out of bounds")
+
+// ErrNotAllowed is returned when a value is not in the allowed set of
+// WithAllowedSet.
+var ErrNotAllowed = errors.New("value not allowed")
+
+// ErrFeature is returned when a specification uses a syntax extension that is
+// not enabled.
+var ErrFeature = errors.New("syntax feature not enabled")
+
+// ErrLimit is returned when a specification exceeds a limit set by an Option.
+var ErrLimit = errors.New("limit exceeded")
+
+// ErrNotIncreasing is returned when values are required to be strictly
+// increasing and are not.
+var ErrNotIncreasing = errors.New("values not strictly increasing")
+
+// Option changes how a specification is parsed. Options are passed to
+// ParseWithOptions and NewIteratorWithOptions, which are the extension point
+// for all variations of parsing. Options may be combined in any way. When the
+// same Option is passed more than once, the last one wins.
+type Option func(*config)
+
+// config holds the settings built from a list of Options. The zero value is
+// the default behavior of Parse and NewIterator.
+type config struct {
+	nonEmpty  bool // Reject an empty or whitespace-only specification
+	ascending bool // Reject decreasing sequences
+	strict    bool // Require the whole expansion to be strictly increasing
+	noDups    bool // Reject values appearing more than once
+	maxItems  int  // Maximum number of items if greater than 0
+	maxSpan   int  // Maximum number of values in a sequence if greater than 0
+	maxValues int  // Maximum number of values in the expansion if above 0
+	lenient   bool // Allow whitespace around items and endpoints
+	bounded   bool // Require values to be within [lo, hi]
+	lo, hi    int  // Bounds of values if bounded
+	partial   bool // Return the values parsed before an error
+	multiLine bool // Allow newlines to separate items
+	positions bool // Report positions in errors even for a single line
+
+	trace    func(TraceEvent) // Called for each item parsed if not nil
+	features FeatureSet       // Syntax extensions enabled
+	used     FeatureSet       // Syntax constructs of the items parsed
+
+	open           bool                     // Allow missing endpoints of sequences
+	openLo, openHi int                      // Values of missing endpoints if open
+	resolve        func(int) int            // Maps each explicit value if not nil
+	word           func(string) (int, bool) // Value of a non-integer word
+
+	every  int                   // Values between progress reports if above 0
+	report func(done, total int) // Called with progress if not nil
+
+	typed          bool // Require values to fit the target integer type
+	typeLo, typeHi int  // Range of the target integer type if typed
+
+	saturate bool        // Clamp integers out of range instead of failing
+	warn     func(error) // Told of each integer clamped if saturate
+
+	modulus int // Values wrap around from modulus-1 to 0 if above 0
+
+	maxBytes int  // Maximum length of the specification if above 0
+	noPanic  bool // Return errors from misused Iterators instead of panicking
+
+	binWidth int              // Bytes per value for WriteTo if above 0
+	binOrder binary.ByteOrder // Byte order for WriteTo if not nil
+
+	restricted bool   // Require values to be in allowed
+	allowed    []span // Normalized spans of the allowed values if restricted
+
+	sep     string  // Separator of items if not "", which means a comma
+	group   rune    // Separator of groups of digits in integers if not 0
+	dialect Dialect // Notation for sequences
+}
+
+// newConfig returns the configuration built from opts.
+func newConfig(opts []Option) *config {
+	c := &config{}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// WithNonEmpty makes an empty or whitespace-only specification an error
+// instead of an empty list.
+//
+//   ParseWithOptions("", WithNonEmpty()) -> nil, ErrEmpty
+func WithNonEmpty() Option {
+	return func(c *config) {
+		c.nonEmpty = true
+	}
+}
+
+// WithAscendingOnly makes a decreasing sequence (E.g., "12...8") an error.
+// This is useful when a decreasing sequence is always a typo.
+//
+//   ParseWithOptions("1,12...8", WithAscendingOnly()) -> nil, ErrDescending
+func WithAscendingOnly() Option {
+	return func(c *config) {
+		c.ascending = true
+	}
+}
+
+// WithStrictlyIncreasing requires each value of the whole expansion to be
+// greater than the one before it, across items as well as within sequences.
+// This is useful for consumers that need sorted input without duplicates.
+//
+//   ParseWithOptions("1...5,9", WithStrictlyIncreasing()) -> [1 2 3 4 5 9], nil
+//   ParseWithOptions("1...5,5", WithStrictlyIncreasing()) ->
+//       nil, ErrNotIncreasing
+func WithStrictlyIncreasing() Option {
+	return func(c *config) {
+		c.strict = true
+	}
+}
+
+// WithNoDuplicates makes a value appearing more than once in the expansion an
+// error. The duplicated value is reported as the Num field of the returned
+// *strconv.NumError.
+//
+//   ParseWithOptions("1...5,9,4", WithNoDuplicates()) -> nil,
+//       &strconv.NumError{Num: "4", Err: ErrDuplicate}
+func WithNoDuplicates() Option {
+	return func(c *config) {
+		c.noDups = true
+	}
+}
+
+// WithMaxItems makes a specification with more than n comma-separated items an
+// error. The first item over the limit is reported as the Num field of the
+// returned *strconv.NumError. This is a cheap guard on untrusted input that is
+// independent of the number of values.
+//
+//   ParseWithOptions("1,2...9,3", WithMaxItems(2)) -> nil,
+//       &strconv.NumError{Num: "3", Err: ErrLimit}
+func WithMaxItems(n int) Option {
+	return func(c *config) {
+		c.maxItems = n
+	}
+}
+
+// WithMaxSpan makes a sequence covering more than n values an error. This keeps
+// one mistyped sequence (E.g., "1...10000000" instead of "1...1000000") from
+// overwhelming downstream systems.
+//
+//   ParseWithOptions("1...10,1...1000", WithMaxSpan(100)) -> nil,
+//       &strconv.NumError{Num: "1...1000", Err: ErrLimit}
+func WithMaxSpan(n int) Option {
+	return func(c *config) {
+		c.maxSpan = n
+	}
+}
+
+// WithLenientWhitespace allows whitespace (including newlines) around items
+// and around the endpoints of sequences. A whitespace-only specification is an
+// empty list. Whitespace within an integer is still an error.
+//
+//   ParseWithOptions(" 1, 5 ... 7,\n9 ", WithLenientWhitespace()) ->
+//       [1 5 6 7 9], nil
+func WithLenientWhitespace() Option {
+	return func(c *config) {
+		c.lenient = true
+	}
+}
+
+// WithBounds makes a value less than lo or greater than hi an error.
+//
+//   ParseWithOptions("1...10", WithBounds(0, 9)) -> nil,
+//       &strconv.NumError{Num: "1...10", Err: ErrOutOfBounds}
+func WithBounds(lo, hi int) Option {
+	return func(c *config) {
+		c.bounded, c.lo, c.hi = true, lo, hi
+	}
+}
+
+// WithAllowedSet makes a value that is not a value of allowed an error, such
+// as for checking that a selection of IDs stays within those a user may use.
+// A nil List allows no values.
+//
+//   allowed, _ := Compile("1...10,20...30")
+//   ParseWithOptions("5...8,25", WithAllowedSet(allowed)) -> [5 6 7 8 25], nil
+//   ParseWithOptions("5...12", WithAllowedSet(allowed)) -> nil,
+//       &strconv.NumError{Num: "5...12", Err: ErrNotAllowed}
+func WithAllowedSet(allowed *List) Option {
+	spans := normalize(seqSpans(allowed.sequences()))
+	return func(c *config) {
+		c.restricted, c.allowed = true, spans
+	}
+}
+
+// isAllowed reports whether every value of s is in the allowed set of c.
+func (c *config) isAllowed(s seq) bool {
+	for _, sp := range s.spans() {
+		if !covered(sp, c.allowed) {
+			return false
+		}
+	}
+	return true
+}
+
+// WithPartial makes ParseWithOptions return the values of the items before
+// the one in error along with the error instead of nil. This lets interactive
+// editors show what was understood up to the failure. It has no effect on
+// NewIteratorWithOptions, which never iterates an invalid specification.
+//
+//   ParseWithOptions("1...3,7,8..9,10", WithPartial()) ->
+//       [1 2 3 7], &strconv.NumError{Num: "8..9", Err: strconv.ErrSyntax}
+func WithPartial() Option {
+	return func(c *config) {
+		c.partial = true
+	}
+}
+
+// WithMultiLine allows a specification to be spread across lines. Each line
+// holds comma-separated items, a newline separates the last item of a line
+// from the first of the next, a comma at the end of a line is allowed and
+// blank lines are ignored. Whitespace is allowed as with WithLenientWhitespace.
+// Errors carry the line and column of the item in error as a *ParseError.
+//
+//   ParseWithOptions("1...3,\n7\n\n9,10", WithMultiLine()) ->
+//       [1 2 3 7 9 10], nil
+func WithMultiLine() Option {
+	return func(c *config) {
+		c.multiLine, c.lenient = true, true
+	}
+}
+
+// WithItemSeparator makes sep separate the items of a specification instead
+// of a comma, such as ";" for data from spreadsheets using commas as decimal
+// points. It does nothing if sep is empty or holds a ".", which would be
+// confused with an ellipsis.
+//
+//   ParseWithOptions("1...3;7", WithItemSeparator(";")) -> [1 2 3 7], nil
+func WithItemSeparator(sep string) Option {
+	return func(c *config) {
+		if sep != "" && !strings.Contains(sep, ".") {
+			c.sep = sep
+		}
+	}
+}
+
+// WithGrouping allows integers written with sep between groups of three
+// digits (E.g., "1.000.000" or "1 000 000"), for data pasted from spreadsheets
+// that group digits that way. The first group has from one to three digits.
+// It has no effect unless WithItemSeparator sets a separator other than a
+// comma that does not hold sep.
+//
+//   ParseWithOptions("1.000...1.002;2.000.000", WithItemSeparator(";"),
+//       WithGrouping('.')) -> [1000 1001 1002 2000000], nil
+func WithGrouping(sep rune) Option {
+	return func(c *config) {
+		c.group = sep
+	}
+}
+
+// ungroup returns tok with the separators of groups of digits removed if c
+// allows them and tok is a correctly grouped integer. Otherwise tok is
+// returned unchanged.
+func (c *config) ungroup(tok string) string {
+	if c.group == 0 || c.sep == "" || c.sep == "," ||
+		strings.ContainsRune(c.sep, c.group) || !strings.ContainsRune(tok, c.group) {
+		return tok
+	}
+	digits := strings.TrimLeft(tok, "+-")
+	if len(tok)-len(digits) > 1 {
+		return tok
+	}
+	groups := strings.Split(digits, string(c.group))
+	for i, g := range groups {
+		if (i == 0 && (g == "" || len(g) > 3)) || (i > 0 && len(g) != 3) ||
+			strings.Trim(g, "0123456789") != "" {
+			return tok
+		}
+	}
+	return tok[:len(tok)-len(digits)] + strings.Join(groups, "")
+}
+
+// WithFeatures enables the syntax extensions in f, which are otherwise
+// rejected with ErrFeature so that strict consumers keep rejecting them. The
+// base syntax of integers and sequences (FeatureRange and FeatureDescending)
+// is always allowed, while the extensions (E.g., FeatureStep and FeatureRepeat)
+// must be enabled one by one, allowing a consumer to accept exactly the
+// dialect it supports. Parse, NewIterator and
+// the functions taking Options are the strict ones. Other functions taking a
+// specification (E.g., Features, Reverse or Sample) accept every extension
+// except the dialects (FeatureRoman and FeatureArithmetic), which only
+// WithFeatures enables.
+//
+//   ParseWithOptions("i...iii,X", WithFeatures(FeatureRoman)) -> [1 2 3 10], nil
+func WithFeatures(f FeatureSet) Option {
+	return func(c *config) {
+		c.features = f
+	}
+}
+
+// featureError returns the error for item using a disabled syntax extension.
+func featureError(fn, item string) error {
+	return &strconv.NumError{Func: fn, Num: item, Err: ErrFeature}
+}
+
+// TraceEvent describes the outcome of parsing one item of a specification.
+type TraceEvent struct {
+	Index  int    // Index (origin 0) of the item
+	Item   string // Text of the item
+	Offset int    // Byte offset of the item in the specification
+	First  int    // First value of the item if Err is nil
+	Last   int    // Last value of the item if Err is nil
+	Err    error  // Error parsing or checking the item, if any
+}
+
+// WithTrace calls f for each item as it is parsed, including the item in
+// error, if any. Items after an error are not parsed. This supports
+// syntax-highlighting editors and debugging.
+//
+//   ParseWithOptions("1,5...3", WithTrace(f)) calls f with
+//       TraceEvent{Index: 0, Item: "1", Offset: 0, First: 1, Last: 1} and
+//       TraceEvent{Index: 1, Item: "5...3", Offset: 2, First: 5, Last: 3}
+func WithTrace(f func(TraceEvent)) Option {
+	return func(c *config) {
+		c.trace = f
+	}
+}
+
+// split breaks spec into its items along with the byte offset of each item.
+func (c *config) split(spec string) ([]string, []int) {
+	var items []string // Items found
+	var offs []int     // Offset of the start of each item in spec
+	lines := []string{spec}
+	if c.multiLine {
+		lines = strings.Split(spec, "\n")
+	}
+	sep := c.sep // Separator of items
+	if sep == "" {
+		sep = ","
+	}
+	lineStart := 0 // Offset of the start of the line
+	for _, line := range lines {
+		parts := strings.Split(line, sep)
+		if c.multiLine {
+			last := len(parts) - 1
+			if strings.TrimSpace(parts[last]) == "" && (last > 0 ||
+				len(lines) > 1) {
+				parts = parts[:last] // Trailing separator or blank line
+			}
+		}
+		partStart := lineStart // Offset of the start of the part
+		for _, part := range parts {
+			item, off := part, partStart
+			if c.lenient {
+				item = strings.TrimLeftFunc(part, unicode.IsSpace)
+				off += len(part) - len(item)
+				item = strings.TrimRightFunc(item, unicode.IsSpace)
+			}
+			items = append(items, item)
+			offs = append(offs, off)
+			partStart += len(part) + len(sep)
+		}
+		lineStart += len(line) + 1
+	}
+	if len(items) == 0 {
+		return []string{""}, []int{0} // All lines blank
+	}
+	return items, offs
+}
+
+// value parses an integer or an endpoint of a sequence. The "fn" parameter
+// is the name of the calling function used in any warning.
+func (c *config) value(fn, tok string) (int, error) {
+	if c.lenient {
+		tok = strings.TrimSpace(tok)
+	}
+	v, err := strconv.Atoi(c.ungroup(tok))
+	if errors.Is(err, strconv.ErrSyntax) && c.word != nil {
+		if w, ok := c.word(tok); ok {
+			return w, nil
+		}
+	}
+	if err != nil {
+		// Atoi may report a range error before seeing the rest of a literal.
+		if lv, ok, lerr := c.literal(fn, tok); ok {
+			v, err = lv, lerr
+		}
+	}
+	clamped := false // Whether v was changed to fit
+	if errors.Is(err, strconv.ErrRange) && c.saturate {
+		clamped, err = true, nil // Atoi returned the nearest int.
+	}
+	if err == nil && c.resolve != nil {
+		v = c.resolve(v)
+	}
+	if err == nil && c.typed && c.saturate {
+		if v < c.typeLo {
+			v, clamped = c.typeLo, true
+		} else if v > c.typeHi {
+			v, clamped = c.typeHi, true
+		}
+	}
+	if clamped {
+		c.warn(&strconv.NumError{Func: fn, Num: tok, Err: strconv.ErrRange})
+	}
+	return v, err
+}
+
+// endpoint parses an endpoint of a sequence, which is the value "missing" if
+// it is left out and c allows that. The "fn" parameter is the name of the
+// calling function used in any warning.
+func (c *config) endpoint(fn, tok string, missing int) (int, error) {
+	if c.open && strings.TrimSpace(tok) == "" {
+		c.used |= FeatureOpenRange
+		return missing, nil
+	}
+	return c.value(fn, tok)
+}
+
+// WithSaturation makes an integer out of range an endpoint at the limit of
+// the range instead of an error. Each value changed is reported to warn as a
+// *strconv.NumError with strconv.ErrRange. The range is that of an int, or
+// that of the target type for NewIteratorAs. This suits forgiving import
+// pipelines.
+//
+//   NewIteratorAs[uint8]("250...300", WithSaturation(warn)) ->
+//       [250 251 252 253 254 255] with warn called for "300"
+func WithSaturation(warn func(error)) Option {
+	return func(c *config) {
+		c.saturate = true
+		c.warn = warn
+		if c.warn == nil {
+			c.warn = func(error) {}
+		}
+	}
+}
+
+// WithMaxValues makes a specification expanding to more than n values in
+// total an error. The item reaching the limit is reported as the Num field of
+// the returned *strconv.NumError. The values left after each exclusion with a
+// step ("!0...100:3") are counted too, as it can split sequences many times.
+//
+//   ParseWithOptions("1...10,20...29", WithMaxValues(15)) -> nil,
+//       &strconv.NumError{Num: "20...29", Err: ErrLimit}
+func WithMaxValues(n int) Option {
+	return func(c *config) {
+		c.maxValues = n
+	}
+}
+
+// WithProgress makes an Iterator call f with the values of its Progress
+// method after every n values returned by Next, which drives a progress bar
+// for a long-running iteration. It does nothing if n is not above 0 or f is
+// nil and has no effect when parsing into a slice.
+//
+//   NewIteratorWithOptions("1...1000", WithProgress(100, f)) ->
+//       f(100, 1000), f(200, 1000) ... f(1000, 1000) called during iteration
+func WithProgress(n int, f func(done, total int)) Option {
+	return func(c *config) {
+		c.every, c.report = n, f
+	}
+}
+
+// WithModular makes a sequence whose end is less than its start count up
+// around a ring of m values instead of down, wrapping from m-1 to 0. Values
+// must be from 0 through m-1. This suits ring-buffer indices and windows of
+// sequence numbers. The wrapped parts are checked by other options as
+// separate sequences. It does nothing if m is not above 0.
+//
+//   ParseWithOptions("250...5", WithModular(256)) ->
+//       [250 251 252 253 254 255 0 1 2 3 4 5], nil
+func WithModular(m int) Option {
+	return func(c *config) {
+		c.modulus = m
+	}
+}
+
+// repetition splits tok written as a value and the number of times it is
+// repeated (E.g., "7x3"). It reports false if tok is not written that way or
+// is a word known to c, such as a service named "x11".
+func (c *config) repetition(tok string) (string, string, bool) {
+	if c.lenient {
+		tok = strings.TrimSpace(tok)
+	}
+	if c.word != nil {
+		if _, ok := c.word(tok); ok {
+			return tok, "", false
+		}
+	}
+	i := strings.LastIndexByte(tok, 'x')
+	if i <= 0 || i == len(tok)-1 || strings.Trim(tok[i+1:], "0123456789") != "" {
+		return tok, "", false
+	}
+	return tok[:i], tok[i+1:], true
+}
+
+// repeats returns the number of extra copies for a value repeated the number
+// of times in tok, which must be positive. The "fn" parameter is the name of
+// the calling function and "item" the item holding tok, both used in any
+// error returned.
+func repeats(fn, item, tok string) (int, error) {
+	n, err := strconv.Atoi(tok)
+	switch {
+	case err != nil:
+		return 0, &strconv.NumError{Func: fn, Num: item, Err: strconv.ErrRange}
+	case n == 0:
+		return 0, &strconv.NumError{Func: fn, Num: item, Err: strconv.ErrSyntax}
+	}
+	return n - 1, nil
+}
+
+// step parses the step of a sequence, which must be a positive integer. The
+// "fn" parameter is the name of the calling function and "item" the item
+// holding tok, both used in any error returned.
+func (c *config) step(fn, item, tok string) (int, error) {
+	if c.lenient {
+		tok = strings.TrimSpace(tok)
+	}
+	v, err := strconv.Atoi(tok)
+	if err != nil || v <= 0 {
+		return 0, &strconv.NumError{Func: fn, Num: item, Err: strconv.ErrSyntax}
+	}
+	return v, nil
+}
+
+// wrap returns the sequences for the item parsed as s, which are two for a
+// sequence wrapping around with WithModular and s otherwise.
+func (c *config) wrap(fn, item string, s seq) ([]seq, error) {
+	if c.modulus <= 0 {
+		return []seq{s}, nil
+	}
+	if lo, hi := s.bounds(); lo < 0 || hi >= c.modulus {
+		return nil, &strconv.NumError{Func: fn, Num: item, Err: ErrOutOfBounds}
+	}
+	if s.step >= 0 {
+		return []seq{s}, nil
+	}
+	// Split at the wrap into "next...m-1" and "0...last", keeping the stride.
+	stride := -s.step
+	top := seq{next: s.next, last: c.modulus - 1, step: stride}.trim()
+	if top.next == top.last {
+		top.step = 0
+	}
+	// The top ends less than one stride below m, so this doesn't overflow.
+	bottom := seq{next: stride - (c.modulus - top.last), last: s.last, step: stride}
+	if bottom.next > bottom.last {
+		return []seq{top}, nil // The stride skips past last.
+	}
+	if bottom.next == bottom.last {
+		bottom.step = 0
+	}
+	return []seq{top, bottom}, nil
+}
+
+// checkSpec checks the whole specification before it is split into items.
+func (c *config) checkSpec(fn, spec string) error {
+	if err := c.checkDialect(fn); err != nil {
+		return err
+	}
+	if c.maxBytes > 0 && len(spec) > c.maxBytes {
+		const keep = 32 // Bytes of a long specification kept in the error
+		if len(spec) > keep {
+			spec = spec[:keep] + "..."
+		}
+		return &strconv.NumError{Func: fn, Num: spec, Err: ErrLimit}
+	}
+	if c.nonEmpty && strings.TrimSpace(spec) == "" {
+		return &strconv.NumError{Func: fn, Num: spec, Err: ErrEmpty}
+	}
+	return nil
+}
+
+// WithMaxBytes makes a specification longer than n bytes an error, which is
+// checked before any parsing. The start of the specification is reported as
+// the Num field of the returned *strconv.NumError.
+//
+//   ParseWithOptions("1,2,3", WithMaxBytes(3)) -> nil,
+//       &strconv.NumError{Num: "1,2,3", Err: ErrLimit}
+func WithMaxBytes(n int) Option {
+	return func(c *config) {
+		c.maxBytes = n
+	}
+}
+
+// WithNoPanic makes Next on an Iterator return the error set in its state
+// instead of panicking when it is invalid, and ErrDone again instead of
+// panicking when it has already returned ErrDone. It has no effect when
+// parsing into a slice.
+func WithNoPanic() Option {
+	return func(c *config) {
+		c.noPanic = true
+	}
+}
+
+// WithBinaryFormat sets the format in which WriteTo writes each value of an
+// Iterator to width bytes (1, 2, 4 or 8) in the byte order "order" (E.g.,
+// binary.LittleEndian). The default is 8 bytes in big-endian order. Any other
+// width makes the Iterator invalid with ErrInvalidArg. It has no effect when
+// parsing into a slice.
+//
+//   NewIteratorWithOptions("1,258", WithBinaryFormat(2, binary.BigEndian))
+//       .WriteTo(w) -> 4, nil with 00 01 01 02 written to w
+func WithBinaryFormat(width int, order binary.ByteOrder) Option {
+	return func(c *config) {
+		c.binWidth, c.binOrder = width, order
+	}
+}
+
+// Limits applied by Untrusted
+const (
+	UntrustedMaxBytes  = 4096  // Length of the specification in bytes
+	UntrustedMaxItems  = 256   // Number of comma-separated items
+	UntrustedMaxValues = 10000 // Number of values in the expansion
+)
+
+// Untrusted returns an Option applying conservative settings for
+// specifications from clients of an API server, so that one option guards
+// against hostile input. It sets limits of UntrustedMaxBytes,
+// UntrustedMaxItems and UntrustedMaxValues, makes Iterators not panic as with
+// WithNoPanic, and requires strict syntax by turning off any whitespace
+// allowance, newline separation and syntax extensions, including steps and
+// repeats, whose short items can expand to many values. Options passed after
+// it override its settings.
+//
+//   ParseWithOptions(strings.Repeat("1,", 300)+"1", Untrusted()) -> nil,
+//       &strconv.NumError{Num: "1", Err: ErrLimit}
+func Untrusted() Option {
+	return func(c *config) {
+		c.maxBytes = UntrustedMaxBytes
+		c.maxItems = UntrustedMaxItems
+		c.maxValues = UntrustedMaxValues
+		c.noPanic = true
+		c.lenient, c.multiLine = false, false
+		c.features = 0
+	}
+}
+
+// checkItems checks the items of a non-empty specification before parsing
+// them.
+func (c *config) checkItems(fn string, items []string) error {
+	if c.maxItems > 0 && len(items) > c.maxItems {
+		return &strconv.NumError{
+			Func: fn,
+			Num:  items[c.maxItems],
+			Err:  ErrLimit,
+		}
+	}
+	return nil
+}
+
+// checkItem checks the sequence s parsed from item.
+func (c *config) checkItem(fn, item string, s seq) error {
+	if c.ascending && s.step < 0 {
+		return &strconv.NumError{Func: fn, Num: item, Err: ErrDescending}
+	}
+	if lo, hi := s.bounds(); c.bounded && (lo < c.lo || hi > c.hi) {
+		return &strconv.NumError{Func: fn, Num: item, Err: ErrOutOfBounds}
+	}
+	if c.restricted && !c.isAllowed(s) {
+		return &strconv.NumError{Func: fn, Num: item, Err: ErrNotAllowed}
+	}
+	if lo, hi := s.bounds(); c.typed && (lo < c.typeLo || hi > c.typeHi) {
+		return &strconv.NumError{Func: fn, Num: item, Err: strconv.ErrRange}
+	}
+	if n := s.len(); c.maxSpan > 0 && (n == 0 || n > uint64(c.maxSpan)) {
+		return &strconv.NumError{Func: fn, Num: item, Err: ErrLimit}
+	}
+	return nil
+}
+
+// checkSeqs checks the sequences parsed from the items of a specification. It
+// returns the number of leading sequences that passed along with any error.
+func (c *config) checkSeqs(fn string, items []string, seqs []seq) (int, error) {
+	if c.maxValues > 0 {
+		var total uint64 // Number of values so far
+		for i, s := range seqs {
+			n := s.len()
+			total += n
+			if n == 0 || total < n || total > uint64(c.maxValues) {
+				return i, &strconv.NumError{
+					Func: fn,
+					Num:  items[i],
+					Err:  ErrLimit,
+				}
+			}
+		}
+	}
+	if c.strict {
+		for i, s := range seqs {
+			if s.step < 0 || s.reps > 0 || (i > 0 && s.next <= seqs[i-1].last) {
+				return i, &strconv.NumError{
+					Func: fn,
+					Num:  items[i],
+					Err:  ErrNotIncreasing,
+				}
+			}
+		}
+	}
+	if c.noDups {
+		if dup, found := findDuplicate(seqs); found {
+			valid := 0 // Index of the second sequence holding dup
+			for seen := false; ; valid++ {
+				if seqs[valid].contains(dup) {
+					if seen || seqs[valid].reps > 0 {
+						break
+					}
+					seen = true
+				}
+			}
+			return valid, &strconv.NumError{
+				Func: fn,
+				Num:  strconv.Itoa(dup),
+				Err:  ErrDuplicate,
+			}
+		}
+	}
+	return len(seqs), nil
+}
+
+// findDuplicate returns a value appearing more than once in seqs, if any.
+func findDuplicate(seqs []seq) (int, bool) {
+	sorted := make([]seq, len(seqs))
+	copy(sorted, seqs)
+	sort.Slice(sorted, func(i, j int) bool {
+		lo1, _ := sorted[i].bounds()
+		lo2, _ := sorted[j].bounds()
+		return lo1 < lo2
+	})
+	var active []seq // Earlier sequences reaching the current one
+	for _, s := range sorted {
+		if s.reps > 0 {
+			return s.next, true // Repeated by itself
+		}
+		lo, _ := s.bounds()
+		kept := active[:0]
+		for _, a := range active {
+			if _, hi := a.bounds(); hi >= lo {
+				kept = append(kept, a)
+			}
+		}
+		active = kept
+		for _, a := range active {
+			if v, ok := firstCommon(a, s); ok {
+				return v, true // Also part of an earlier sequence
+			}
+		}
+		active = append(active, s)
+	}
+	return 0, false
+}
+
+// firstCommon returns the smallest value of both a and b, if any. Sequences
+// with strides above one may overlap without sharing a value (E.g.,
+// "0...10:2" and "1...11:2").
+func firstCommon(a, b seq) (int, bool) {
+	loA, hiA := a.bounds()
+	loB, hiB := b.bounds()
+	lo, hi := loA, hiA
+	if loB > lo {
+		lo = loB
+	}
+	if hiB < hi {
+		hi = hiB
+	}
+	if lo > hi {
+		return 0, false
+	}
+	p, q := a.stride(), b.stride()
+	if p <= 1 && q <= 1 {
+		return lo, true
+	}
+	if p == 0 {
+		p = 1
+	}
+	if q == 0 {
+		q = 1
+	}
+	// Solve v = loA (mod p) and v = loB (mod q) as in the Chinese remainder
+	// theorem, working in big integers to avoid overflow.
+	bp, bq := new(big.Int).SetUint64(p), new(big.Int).SetUint64(q)
+	x := new(big.Int)
+	g := new(big.Int).GCD(x, nil, bp, bq) // p*x = g (mod q)
+	diff := new(big.Int).Sub(big.NewInt(int64(loB)), big.NewInt(int64(loA)))
+	if new(big.Int).Mod(diff, g).Sign() != 0 {
+		return 0, false // The strides never line up.
+	}
+	qg := new(big.Int).Quo(bq, g)
+	t := new(big.Int).Quo(diff, g)
+	t.Mul(t, x).Mod(t, qg)
+	v := new(big.Int).Mul(bp, t)
+	v.Add(v, big.NewInt(int64(loA))) // A common value, possibly below lo
+	lcm := new(big.Int).Mul(bp, qg)
+	// Move up to the first common value at or above lo.
+	d := new(big.Int).Sub(big.NewInt(int64(lo)), v)
+	d.Add(d, lcm).Sub(d, big.NewInt(1))
+	d.Div(d, lcm).Mul(d, lcm)
+	v.Add(v, d)
+	if v.Cmp(big.NewInt(int64(hi))) > 0 {
+		return 0, false
+	}
+	return int(v.Int64()), true
+}
+
+// NewIteratorWithOptions is NewIterator with the behavior changed by opts.
+//
+// Potential errors set in state during creation of an Iterator:
+//
+//   strconv.ErrSyntax - Error parsing integer or sequence notation
+//   strconv.ErrRange - Integer out of range
+//   ErrEmpty - Empty specification with WithNonEmpty
+//   ErrDescending - Decreasing sequence with WithAscendingOnly
+//   ErrNotIncreasing - Unordered values with WithStrictlyIncreasing
+//   ErrDuplicate - Repeated value with WithNoDuplicates
+//   ErrLimit - Limit exceeded with WithMaxBytes, WithMaxItems, WithMaxSpan or
+//       WithMaxValues
+//   ErrOutOfBounds - Value out of bounds with WithBounds
+//   ErrNotAllowed - Value not in the allowed set with WithAllowedSet
+//   ErrFeature - Syntax extension not enabled with WithFeatures
+func NewIteratorWithOptions(spec string, opts ...Option) *Iterator {
+	return newIterator("NewIteratorWithOptions", spec, newConfig(opts))
+}
+
+// newIterator returns an Iterator for spec parsed using the configuration c,
+// which also sets how the Iterator behaves. The "fn" parameter is the name of
+// the calling function used in any error returned.
+func newIterator(fn, spec string, c *config) *Iterator {
+	seqs, err := parseSpec(fn, spec, c)
+	it := &Iterator{
+		seqs: seqs,
+		err:  err,
+	}
+	if c.every > 0 && c.report != nil {
+		it.every, it.report = c.every, c.report
+	}
+	it.noPanic = c.noPanic
+	if c.binWidth != 0 || c.binOrder != nil {
+		if it.err == nil && !validWidth(c.binWidth) {
+			it.seqs, it.err = nil, fmt.Errorf("intlist.%s: binary width %d: %w", fn,
+				c.binWidth, ErrInvalidArg)
+		}
+		it.binWidth, it.binOrder = c.binWidth, c.binOrder
+	}
+	return it
+}
+
+// ParseWithOptions is Parse with the behavior changed by opts.
+//
+//   ParseWithOptions("1...3", WithNonEmpty()) -> [1 2 3], nil
+//
+// Potential errors returned:
+//
+//   strconv.ErrSyntax - Error parsing integer or sequence notation
+//   strconv.ErrRange - Integer out of range
+//   ErrEmpty - Empty specification with WithNonEmpty
+//   ErrDescending - Decreasing sequence with WithAscendingOnly
+//   ErrNotIncreasing - Unordered values with WithStrictlyIncreasing
+//   ErrDuplicate - Repeated value with WithNoDuplicates
+//   ErrLimit - Limit exceeded with WithMaxBytes, WithMaxItems, WithMaxSpan or
+//       WithMaxValues
+//   ErrOutOfBounds - Value out of bounds with WithBounds
+//   ErrNotAllowed - Value not in the allowed set with WithAllowedSet
+//   ErrFeature - Syntax extension not enabled with WithFeatures
+func ParseWithOptions(spec string, opts ...Option) ([]int, error) {
+	c := newConfig(opts)
+	seqs, err := parseSpec("ParseWithOptions", spec, c)
+	if err != nil && !c.partial {
+		return nil, err
+	}
+	it := &Iterator{seqs: seqs}
+	return it.rest(), err
+}
+
+// ParseWithBounds is Parse for values from lo through hi, where a sequence may
+// leave out an endpoint to mean lo or hi, as in page or shard selection where
+// only the caller knows the end (E.g., "5..." or "...20"). Values outside of
+// the bounds are an error.
+//
+//   ParseWithBounds("...3,8...", 1, 10) -> [1 2 3 8 9 10], nil
+//   ParseWithBounds("10...", 1, 12) -> [10 11 12], nil
+//
+// Potential errors returned:
+//
+//   strconv.ErrSyntax - Error parsing integer or sequence notation
+//   strconv.ErrRange - Integer out of range
+//   ErrOutOfBounds - Value less than lo or greater than hi
+//   ErrInvalidArg - lo is greater than hi
+func ParseWithBounds(spec string, lo, hi int) ([]int, error) {
+	const fn = "ParseWithBounds"
+	if lo > hi {
+		return nil, fmt.Errorf("intlist.%s: bounds [%d, %d]: %w", fn, lo, hi, ErrInvalidArg)
+	}
+	seqs, err := parseSpec(fn, spec, &config{
+		bounded: true,
+		lo:      lo,
+		hi:      hi,
+		open:    true,
+		openLo:  lo,
+		openHi:  hi,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return (&Iterator{seqs: seqs}).rest(), nil
+}
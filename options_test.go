@@ -0,0 +1,420 @@
+// Copyright 2020 Brian E. Holland. All rights reserved.
+// The use of this source code is governed by an MIT license
+// that can be found in the LICENSE file.
+
+package intlist_test
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/brianholland99/intlist"
+	"github.com/google/go-cmp/cmp"
+)
+
+type optionsTest struct {
+	in   string
+	opts []intlist.Option
+	out  []int
+	err  error
+}
+
+// These test the behavior of each Option through ParseWithOptions.
+var optionsTests = []optionsTest{
+	// No options
+	{"", nil, []int{}, nil},               // Empty list
+	{"1...3", nil, []int{1, 2, 3}, nil},   // Same as Parse
+	{"1,,3", nil, nil, strconv.ErrSyntax}, // Empty item
+	// WithNonEmpty
+	{"", []intlist.Option{intlist.WithNonEmpty()}, nil, intlist.ErrEmpty},
+	{" \t", []intlist.Option{intlist.WithNonEmpty()}, nil, intlist.ErrEmpty},
+	{"7", []intlist.Option{intlist.WithNonEmpty()}, []int{7}, nil},
+	// WithAscendingOnly
+	{"1,12...8", []intlist.Option{intlist.WithAscendingOnly()}, nil,
+		intlist.ErrDescending},
+	{"1,3...3,4...5", []intlist.Option{intlist.WithAscendingOnly()},
+		[]int{1, 3, 4, 5}, nil},
+	// WithStrictlyIncreasing
+	{"1...5,9", []intlist.Option{intlist.WithStrictlyIncreasing()},
+		[]int{1, 2, 3, 4, 5, 9}, nil},
+	{"1...5,5", []intlist.Option{intlist.WithStrictlyIncreasing()}, nil,
+		intlist.ErrNotIncreasing},
+	{"9...7", []intlist.Option{intlist.WithStrictlyIncreasing()}, nil,
+		intlist.ErrNotIncreasing},
+	// WithNoDuplicates
+	{"1...5,9,4", []intlist.Option{intlist.WithNoDuplicates()}, nil,
+		intlist.ErrDuplicate},
+	{"20...10,1,15", []intlist.Option{intlist.WithNoDuplicates()}, nil,
+		intlist.ErrDuplicate},
+	{"9,1...5,8...6", []intlist.Option{intlist.WithNoDuplicates()},
+		[]int{9, 1, 2, 3, 4, 5, 8, 7, 6}, nil},
+	{"1...3,7x2", []intlist.Option{intlist.WithNoDuplicates(),
+		intlist.WithFeatures(intlist.FeatureRepeat)}, nil,
+		intlist.ErrDuplicate}, // Repetition
+	{"0...8:4,2...10:4,11...1:5", []intlist.Option{intlist.WithNoDuplicates(), steps},
+		nil, intlist.ErrDuplicate}, // Strides line up
+	{"0...8:4,2...10:4,9...1:2", []intlist.Option{intlist.WithNoDuplicates(), steps},
+		[]int{0, 4, 8, 2, 6, 10, 9, 7, 5, 3, 1}, nil}, // Strides interleave
+	// WithMaxItems
+	{"1,2...9,3", []intlist.Option{intlist.WithMaxItems(2)}, nil,
+		intlist.ErrLimit},
+	{"1,2...9", []intlist.Option{intlist.WithMaxItems(2)},
+		[]int{1, 2, 3, 4, 5, 6, 7, 8, 9}, nil},
+	{"", []intlist.Option{intlist.WithMaxItems(1)}, []int{}, nil},
+	// WithMaxSpan
+	{"1...10,1...1000", []intlist.Option{intlist.WithMaxSpan(100)}, nil,
+		intlist.ErrLimit},
+	{"-2...2,7", []intlist.Option{intlist.WithMaxSpan(5)},
+		[]int{-2, -1, 0, 1, 2, 7}, nil},
+	// WithMaxValues
+	{"1...10,20...29", []intlist.Option{intlist.WithMaxValues(15)}, nil,
+		intlist.ErrLimit},
+	{"1...10,20...24", []intlist.Option{intlist.WithMaxValues(15)},
+		[]int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 20, 21, 22, 23, 24}, nil},
+	// WithLenientWhitespace
+	{" 1, 5 ... 7,\n9 ", []intlist.Option{intlist.WithLenientWhitespace()},
+		[]int{1, 5, 6, 7, 9}, nil},
+	{" \t", []intlist.Option{intlist.WithLenientWhitespace()}, []int{}, nil},
+	{"1 2", []intlist.Option{intlist.WithLenientWhitespace()}, nil,
+		strconv.ErrSyntax},
+	{"1, ,2", []intlist.Option{intlist.WithLenientWhitespace()}, nil,
+		strconv.ErrSyntax},
+	// WithBounds
+	{"1...10", []intlist.Option{intlist.WithBounds(0, 9)}, nil,
+		intlist.ErrOutOfBounds},
+	{"-1", []intlist.Option{intlist.WithBounds(0, 9)}, nil,
+		intlist.ErrOutOfBounds},
+	{"9...0", []intlist.Option{intlist.WithBounds(0, 9)},
+		[]int{9, 8, 7, 6, 5, 4, 3, 2, 1, 0}, nil},
+	// WithPartial
+	{"1...3,7,8..9,10", []intlist.Option{intlist.WithPartial()},
+		[]int{1, 2, 3, 7}, strconv.ErrSyntax},
+	{"x", []intlist.Option{intlist.WithPartial()}, []int{}, strconv.ErrSyntax},
+	{"", []intlist.Option{intlist.WithPartial(), intlist.WithNonEmpty()},
+		[]int{}, intlist.ErrEmpty},
+	{"1,5,3,3", []intlist.Option{intlist.WithPartial(),
+		intlist.WithNoDuplicates()}, []int{1, 5, 3}, intlist.ErrDuplicate},
+	{"1,5,3", []intlist.Option{intlist.WithPartial(),
+		intlist.WithStrictlyIncreasing()}, []int{1, 5}, intlist.ErrNotIncreasing},
+	// WithMultiLine
+	{"1...3,\n7\n\n 9, 10,\n", []intlist.Option{intlist.WithMultiLine()},
+		[]int{1, 2, 3, 7, 9, 10}, nil},
+	{"\n\n", []intlist.Option{intlist.WithMultiLine()}, []int{}, nil},
+	{"1,,\n2", []intlist.Option{intlist.WithMultiLine()}, nil,
+		strconv.ErrSyntax},
+	{"1\n2", nil, nil, strconv.ErrSyntax}, // Newline without option
+	// WithFeatures
+	{"i...iii,X,MMXX", []intlist.Option{intlist.WithFeatures(intlist.FeatureRoman)},
+		[]int{1, 2, 3, 10, 2020}, nil},
+	{"IIII", []intlist.Option{intlist.WithFeatures(intlist.FeatureRoman)}, nil,
+		strconv.ErrSyntax}, // Not the standard form
+	{"Xv", []intlist.Option{intlist.WithFeatures(intlist.FeatureRoman)}, nil,
+		strconv.ErrSyntax}, // Mixed case
+	{"iv", nil, nil, strconv.ErrSyntax}, // Dialect not enabled
+	{"1,5...3", []intlist.Option{intlist.WithFeatures(0)},
+		[]int{1, 5, 4, 3}, nil}, // Base syntax is always allowed.
+	{"0...10:5", []intlist.Option{intlist.WithFeatures(0)}, nil, intlist.ErrFeature},
+	{"7x2", []intlist.Option{intlist.WithFeatures(0)}, nil, intlist.ErrFeature},
+	{"0...10:5,7x2", []intlist.Option{intlist.WithFeatures(intlist.FeatureStep |
+		intlist.FeatureRepeat)}, []int{0, 5, 10, 7, 7}, nil},
+	{"0...10:5,7x2", []intlist.Option{intlist.WithFeatures(intlist.FeatureStep)},
+		nil, intlist.ErrFeature}, // Only the enabled extensions
+	{"0...10:5,7x2", []intlist.Option{intlist.WithFeatures(intlist.FeatureRepeat)},
+		nil, intlist.ErrFeature},
+	// Combined options
+	{" 3...1 ", []intlist.Option{intlist.WithLenientWhitespace(),
+		intlist.WithAscendingOnly()}, nil, intlist.ErrDescending},
+}
+
+func TestParseWithOptions(t *testing.T) {
+	for _, test := range optionsTests {
+		out, err := intlist.ParseWithOptions(test.in, test.opts...)
+		if !cmp.Equal(out, test.out) || !errors.Is(err, test.err) {
+			t.Errorf("ParseWithOptions(%q, %d options) = (%v), (%v) -- "+
+				"wanted (%v), (%v)", test.in, len(test.opts), out, err,
+				test.out, test.err)
+		}
+	}
+}
+
+// The duplicated value must be identified in the error.
+func TestWithNoDuplicatesValue(t *testing.T) {
+	_, err := intlist.ParseWithOptions("20...10,1,15...30",
+		intlist.WithNoDuplicates())
+	var numErr *strconv.NumError
+	if !errors.As(err, &numErr) || numErr.Num != "15" {
+		t.Errorf("ParseWithOptions duplicate error = %v -- wanted Num \"15\"",
+			err)
+	}
+}
+
+func TestWithTrace(t *testing.T) {
+	var events []intlist.TraceEvent
+	trace := intlist.WithTrace(func(e intlist.TraceEvent) {
+		events = append(events, e)
+	})
+	_, _ = intlist.ParseWithOptions("1, 5...3,x,9", trace,
+		intlist.WithLenientWhitespace())
+	want := []intlist.TraceEvent{
+		{Index: 0, Item: "1", Offset: 0, First: 1, Last: 1},
+		{Index: 1, Item: "5...3", Offset: 3, First: 5, Last: 3},
+		{Index: 2, Item: "x", Offset: 9, Err: strconv.ErrSyntax},
+	}
+	if len(events) != len(want) {
+		t.Fatalf("WithTrace got %d events -- wanted %d", len(events), len(want))
+	}
+	for i, e := range events {
+		if e.Index != want[i].Index || e.Item != want[i].Item ||
+			e.Offset != want[i].Offset || !errors.Is(e.Err, want[i].Err) ||
+			(e.Err == nil && (e.First != want[i].First ||
+				e.Last != want[i].Last)) {
+			t.Errorf("WithTrace event %d = %+v -- wanted %+v", i, e, want[i])
+		}
+	}
+}
+
+func TestWithProgress(t *testing.T) {
+	var got [][2]int
+	report := func(done, total int) { got = append(got, [2]int{done, total}) }
+	it := intlist.NewIteratorWithOptions("1...5", intlist.WithProgress(2, report))
+	collect(it)
+	want := [][2]int{{2, 5}, {4, 5}}
+	if !cmp.Equal(got, want) {
+		t.Errorf("WithProgress(2, f) called f with %v -- wanted %v", got, want)
+	}
+}
+
+func TestWithSaturation(t *testing.T) {
+	var warned []string
+	warn := func(err error) {
+		var numErr *strconv.NumError
+		if errors.As(err, &numErr) && errors.Is(err, strconv.ErrRange) {
+			warned = append(warned, numErr.Num)
+		}
+	}
+	it := intlist.NewIteratorAs[int8]("125...300,-5,-99999999999999999999999",
+		intlist.WithSaturation(warn))
+	var out []int8
+	for {
+		val, err := it.Next()
+		if err == intlist.ErrDone {
+			break
+		}
+		out = append(out, val)
+	}
+	if want := []int8{125, 126, 127, -5, -128}; !cmp.Equal(out, want) {
+		t.Errorf("NewIteratorAs[int8] WithSaturation() = %v -- wanted %v", out, want)
+	}
+	// Each value is reported once, even if beyond the range of an int.
+	if want := []string{"300", "-99999999999999999999999"}; !cmp.Equal(warned, want) {
+		t.Errorf("WithSaturation() warned of %q -- wanted %q", warned, want)
+	}
+}
+
+// steps is an Option enabling sequences with a step.
+var steps = intlist.WithFeatures(intlist.FeatureStep)
+
+var modularTests = []optionsTest{
+	// Good cases
+	{"250...5", []intlist.Option{intlist.WithModular(256)},
+		[]int{250, 251, 252, 253, 254, 255, 0, 1, 2, 3, 4, 5}, nil}, // Wrapping
+	{"255...0", []intlist.Option{intlist.WithModular(256)}, []int{255, 0}, nil},
+	{"1...3,7", []intlist.Option{intlist.WithModular(8)}, []int{1, 2, 3, 7}, nil},
+	{"6...1", []intlist.Option{intlist.WithModular(8), intlist.WithAscendingOnly()},
+		[]int{6, 7, 0, 1}, nil}, // Wrapped parts increase
+	{"6...1:3", []intlist.Option{intlist.WithModular(8), steps}, []int{6, 1},
+		nil}, // Step across wrap
+	{"6...0:3", []intlist.Option{intlist.WithModular(8), steps}, []int{6},
+		nil}, // Step past last
+	{"250...1:2", []intlist.Option{intlist.WithModular(256), steps},
+		[]int{250, 252, 254, 0}, nil}, // Step onto 0
+	// Error cases
+	{"256", []intlist.Option{intlist.WithModular(256)}, nil, intlist.ErrOutOfBounds},
+	{"-1...3", []intlist.Option{intlist.WithModular(256)}, nil, intlist.ErrOutOfBounds},
+	{"6...1", []intlist.Option{intlist.WithModular(8), intlist.WithMaxValues(3)},
+		nil, intlist.ErrLimit}, // Limit counts both parts
+	{"6...1,0", []intlist.Option{intlist.WithModular(8), intlist.WithNoDuplicates()},
+		nil, intlist.ErrDuplicate},
+}
+
+func TestWithModular(t *testing.T) {
+	for _, test := range modularTests {
+		out, err := intlist.ParseWithOptions(test.in, test.opts...)
+		if !cmp.Equal(out, test.out) || !errors.Is(err, test.err) {
+			t.Errorf("ParseWithOptions(%q, WithModular...) = (%v), (%v) -- wanted (%v), (%v)",
+				test.in, out, err, test.out, test.err)
+		}
+	}
+}
+
+// allowed returns an Option allowing the values of spec, compiled with opts.
+func allowed(spec string, opts ...intlist.Option) intlist.Option {
+	list, err := intlist.Compile(spec, opts...)
+	if err != nil {
+		panic(err)
+	}
+	return intlist.WithAllowedSet(list)
+}
+
+var allowedSetTests = []optionsTest{
+	// Good cases
+	{"5...8,25", []intlist.Option{allowed("1...10,20...30")}, []int{5, 6, 7, 8, 25}, nil},
+	{"12...8", []intlist.Option{allowed("11...15,5...10")}, []int{12, 11, 10, 9, 8},
+		nil}, // Adjacent allowed items
+	{"", []intlist.Option{allowed("")}, []int{}, nil},
+	{"255...1", []intlist.Option{intlist.WithModular(256), allowed("0...3,250...255")},
+		[]int{255, 0, 1}, nil}, // Wrapped parts checked separately
+	{"1...9:4", []intlist.Option{allowed("1,5,9"), steps}, []int{1, 5, 9},
+		nil}, // Step over holes
+	{"10...50:10", []intlist.Option{allowed("0...1000000000:2", steps), steps},
+		[]int{10, 20, 30, 40, 50}, nil},
+	// Error cases
+	{"5...12", []intlist.Option{allowed("1...10,20...30")}, nil, intlist.ErrNotAllowed},
+	{"1,15", []intlist.Option{allowed("1...10,20...30")}, nil, intlist.ErrNotAllowed},
+	{"8...22", []intlist.Option{allowed("1...10,20...30")}, nil, intlist.ErrNotAllowed},
+	{"0", []intlist.Option{intlist.WithAllowedSet(nil)}, nil, intlist.ErrNotAllowed},
+	{"10...50:5", []intlist.Option{allowed("0...1000000000:2", steps), steps}, nil,
+		intlist.ErrNotAllowed},
+	{"1...5", []intlist.Option{allowed("0...1000000000:2", steps)}, nil, intlist.ErrNotAllowed},
+}
+
+func TestWithAllowedSet(t *testing.T) {
+	for _, test := range allowedSetTests {
+		out, err := intlist.ParseWithOptions(test.in, test.opts...)
+		if !cmp.Equal(out, test.out) || !errors.Is(err, test.err) {
+			t.Errorf("ParseWithOptions(%q, WithAllowedSet...) = (%v), (%v) -- wanted (%v), (%v)",
+				test.in, out, err, test.out, test.err)
+		}
+	}
+}
+
+var separatorTests = []optionsTest{
+	// Good cases
+	{"1...3;7", []intlist.Option{intlist.WithItemSeparator(";")}, []int{1, 2, 3, 7}, nil},
+	{"1 | 5...4", []intlist.Option{intlist.WithItemSeparator("|"),
+		intlist.WithLenientWhitespace()}, []int{1, 5, 4}, nil},
+	{"1.000...1.002;-2.000.000", []intlist.Option{intlist.WithItemSeparator(";"),
+		intlist.WithGrouping('.')}, []int{1000, 1001, 1002, -2000000}, nil},
+	{"1 000;20 000...20 001", []intlist.Option{intlist.WithItemSeparator(";"),
+		intlist.WithGrouping(' ')}, []int{1000, 20000, 20001}, nil},
+	{"1,000;5", []intlist.Option{intlist.WithItemSeparator(";"),
+		intlist.WithGrouping(',')}, []int{1000, 5}, nil},
+	{"1,2", []intlist.Option{intlist.WithItemSeparator("")}, []int{1, 2},
+		nil}, // Empty separator ignored
+	{"1,2", []intlist.Option{intlist.WithItemSeparator(".")}, []int{1, 2},
+		nil}, // Separator confused with an ellipsis ignored
+	// Error cases
+	{"1,2", []intlist.Option{intlist.WithItemSeparator(";")}, nil, strconv.ErrSyntax},
+	{"1.000", []intlist.Option{intlist.WithGrouping('.')}, nil,
+		strconv.ErrSyntax}, // Items separated by commas
+	{"10.00", []intlist.Option{intlist.WithItemSeparator(";"),
+		intlist.WithGrouping('.')}, nil, strconv.ErrSyntax}, // Short group
+	{"1000.000", []intlist.Option{intlist.WithItemSeparator(";"),
+		intlist.WithGrouping('.')}, nil, strconv.ErrSyntax}, // Long first group
+	{".000", []intlist.Option{intlist.WithItemSeparator(";"),
+		intlist.WithGrouping('.')}, nil, strconv.ErrSyntax}, // Empty first group
+	{"1;;2", []intlist.Option{intlist.WithItemSeparator(";")}, nil, strconv.ErrSyntax},
+}
+
+func TestWithItemSeparator(t *testing.T) {
+	for _, test := range separatorTests {
+		out, err := intlist.ParseWithOptions(test.in, test.opts...)
+		if !cmp.Equal(out, test.out) || !errors.Is(err, test.err) {
+			t.Errorf("ParseWithOptions(%q, WithItemSeparator...) = (%v), (%v) -- wanted (%v), (%v)",
+				test.in, out, err, test.out, test.err)
+		}
+	}
+	// Offsets allow for the length of the separator.
+	var offs []int
+	trace := intlist.WithTrace(func(e intlist.TraceEvent) { offs = append(offs, e.Offset) })
+	if _, err := intlist.ParseWithOptions("1 / 2 / 3", intlist.WithItemSeparator(" / "),
+		trace); err != nil || !cmp.Equal(offs, []int{0, 4, 8}) {
+		t.Errorf("WithItemSeparator offsets = %v, error = %v -- wanted [0 4 8], nil", offs, err)
+	}
+}
+
+var untrustedTests = []optionsTest{
+	// Good cases
+	{"1...100,7", []intlist.Option{intlist.Untrusted()}, nil, nil}, // Output not checked
+	{"1,2", []intlist.Option{intlist.WithMaxBytes(3)}, []int{1, 2}, nil},
+	{"1...20000", []intlist.Option{intlist.Untrusted(), intlist.WithMaxValues(0)},
+		nil, nil}, // Later options override
+	{"0...10:3,7x2", []intlist.Option{intlist.Untrusted(),
+		intlist.WithFeatures(intlist.FeatureStep | intlist.FeatureRepeat)},
+		[]int{0, 3, 6, 9, 7, 7}, nil}, // Extensions enabled after
+	// Error cases
+	{"1,2,3", []intlist.Option{intlist.WithMaxBytes(3)}, nil, intlist.ErrLimit},
+	{strings.Repeat("1,", 300) + "1", []intlist.Option{intlist.Untrusted()},
+		nil, intlist.ErrLimit}, // Too many items
+	{"1...10001", []intlist.Option{intlist.Untrusted()}, nil, intlist.ErrLimit},
+	{strings.Repeat(" ", 5000), []intlist.Option{intlist.Untrusted()},
+		nil, intlist.ErrLimit}, // Too long
+	{"1, 2", []intlist.Option{intlist.WithLenientWhitespace(), intlist.Untrusted()},
+		nil, strconv.ErrSyntax}, // Strict syntax
+	{"0...10:3", []intlist.Option{intlist.WithFeatures(intlist.FeatureStep),
+		intlist.Untrusted()}, nil, intlist.ErrFeature}, // No steps
+	{"7x2", []intlist.Option{intlist.Untrusted()}, nil, intlist.ErrFeature}, // No repeats
+}
+
+func TestUntrusted(t *testing.T) {
+	for _, test := range untrustedTests {
+		out, err := intlist.ParseWithOptions(test.in, test.opts...)
+		if !errors.Is(err, test.err) || (test.out != nil && !cmp.Equal(out, test.out)) {
+			t.Errorf("ParseWithOptions(%.20q, ...) = (%v), (%v) -- wanted (%v), (%v)",
+				test.in, out, err, test.out, test.err)
+		}
+	}
+}
+
+func TestWithNoPanic(t *testing.T) {
+	it := intlist.NewIteratorWithOptions("1..2", intlist.WithNoPanic())
+	if _, err := it.Next(); !errors.Is(err, strconv.ErrSyntax) {
+		t.Errorf("Next() on invalid iterator error = (%v) -- wanted (%v)", err, strconv.ErrSyntax)
+	}
+	it = intlist.NewIteratorWithOptions("1", intlist.Untrusted())
+	it.Next()
+	for i := 0; i < 2; i++ {
+		if _, err := it.Next(); err != intlist.ErrDone {
+			t.Errorf("Next() after end error = (%v) -- wanted (%v)", err, intlist.ErrDone)
+		}
+	}
+}
+
+type parseWithBoundsTest struct {
+	in     string
+	lo, hi int
+	out    []int
+	err    error
+}
+
+var parseWithBoundsTests = []parseWithBoundsTest{
+	// Good cases
+	{"...3,8...", 1, 10, []int{1, 2, 3, 8, 9, 10}, nil}, // Both ends
+	{"10...", 1, 12, []int{10, 11, 12}, nil},            // From 10 to the end
+	{"...", 4, 6, []int{4, 5, 6}, nil},                  // Everything
+	{"...1", 1, 5, []int{1}, nil},                       // To the first
+	{"2,4", 1, 5, []int{2, 4}, nil},                     // No open ranges
+	{"", 1, 5, []int{}, nil},                            // Empty list
+	// Error cases
+	{"0...", 1, 5, nil, intlist.ErrOutOfBounds},
+	{"...9", 1, 5, nil, intlist.ErrOutOfBounds},
+	{"1...,", 1, 5, nil, strconv.ErrSyntax},
+	{"0...:2", 0, 5, nil, intlist.ErrFeature}, // Step not enabled
+	{"1", 5, 1, nil, intlist.ErrInvalidArg},
+}
+
+func TestParseWithBounds(t *testing.T) {
+	for _, test := range parseWithBoundsTests {
+		out, err := intlist.ParseWithBounds(test.in, test.lo, test.hi)
+		if !cmp.Equal(out, test.out) || !errors.Is(err, test.err) {
+			t.Errorf("ParseWithBounds(%q, %d, %d) = (%v), (%v) -- wanted (%v), (%v)",
+				test.in, test.lo, test.hi, out, err, test.out, test.err)
+		}
+	}
+	// Open ranges are otherwise syntax errors.
+	if _, err := intlist.Parse("5..."); !errors.Is(err, strconv.ErrSyntax) {
+		t.Errorf("Parse(%q) error = %v -- wanted %v", "5...", err, strconv.ErrSyntax)
+	}
+}
@@ -0,0 +1,77 @@
+// Copyright 2020 Brian E. Holland. All rights reserved.
+// The use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package intlist
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// NewPacedIterator validates the specification and sets the state for
+// iterating through the values of its expansion with each call of Next
+// waiting to receive from tick first. Passing the channel of a time.Ticker
+// drip-feeds values to a rate-limited consumer, and tests can pass a channel
+// they send to themselves. Values are not held back once tick is closed.
+//
+//   NewPacedIterator("1...3", time.NewTicker(time.Second).C) ->
+//       [1 2 3] with one value per second
+//
+// Potential errors set in state during creation of an Iterator:
+//
+//   strconv.ErrSyntax - Error parsing integer or sequence notation
+//   strconv.ErrRange - Integer out of range
+func NewPacedIterator(spec string, tick <-chan time.Time) *Iterator {
+	return paced("NewPacedIterator", spec, func(ctx context.Context) error {
+		select {
+		case <-tick:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	})
+}
+
+// NewRateIterator validates the specification and sets the state for
+// iterating through the values of its expansion no faster than one value
+// every interval. The first value is returned at once, and each call of Next
+// after that waits for whatever is left of interval since the previous value.
+//
+//   NewRateIterator("1...3", 100*time.Millisecond) ->
+//       [1 2 3] taking at least 200ms
+//
+// Potential errors set in state during creation of an Iterator:
+//
+//   strconv.ErrSyntax - Error parsing integer or sequence notation
+//   strconv.ErrRange - Integer out of range
+//   ErrInvalidArg - The "interval" parameter is negative
+func NewRateIterator(spec string, interval time.Duration) *Iterator {
+	const fn = "NewRateIterator"
+	if interval < 0 {
+		return &Iterator{err: fmt.Errorf("intlist.%s: interval %v: %w", fn, interval, ErrInvalidArg)}
+	}
+	var last time.Time // When the previous value was returned
+	return paced(fn, spec, func(ctx context.Context) error {
+		if !last.IsZero() {
+			timer := time.NewTimer(time.Until(last.Add(interval)))
+			defer timer.Stop()
+			select {
+			case <-timer.C:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+		last = time.Now()
+		return nil
+	})
+}
+
+// paced returns an Iterator for spec calling pause before each value. The
+// "fn" parameter is the name of the calling function used in any error
+// returned.
+func paced(fn, spec string, pause func(context.Context) error) *Iterator {
+	seqs, err := parseSeqs(fn, spec)
+	return &Iterator{seqs: seqs, err: err, pause: pause}
+}
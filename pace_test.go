@@ -0,0 +1,79 @@
+// Copyright 2020 Brian E. Holland. All rights reserved.
+// The use of this source code is governed by an MIT license
+// that can be found in the LICENSE file.
+
+package intlist_test
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/brianholland99/intlist"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestPacedIterator(t *testing.T) {
+	tick := make(chan time.Time)
+	it := intlist.NewPacedIterator("1...2", tick)
+	got := make(chan int)
+	go func() {
+		for _, want := range []int{1, 2} {
+			tick <- time.Time{}
+			if val := <-got; val != want {
+				t.Errorf("Next() after tick = %d -- wanted %d", val, want)
+			}
+		}
+	}()
+	for {
+		val, err := it.Next()
+		if err == intlist.ErrDone {
+			break
+		}
+		got <- val
+	}
+	if it := intlist.NewPacedIterator("1..2", tick); !errors.Is(it.Err(), strconv.ErrSyntax) {
+		t.Errorf("NewPacedIterator(%q) error = (%v) -- wanted (%v)", "1..2", it.Err(), strconv.ErrSyntax)
+	}
+}
+
+func TestRateIterator(t *testing.T) {
+	const interval = 20 * time.Millisecond
+	start := time.Now()
+	out := collect(intlist.NewRateIterator("1...3", interval))
+	if elapsed := time.Since(start); elapsed < 2*interval {
+		t.Errorf("NewRateIterator(%q, %v) took %v -- wanted at least %v",
+			"1...3", interval, elapsed, 2*interval)
+	}
+	if want := []int{1, 2, 3}; !cmp.Equal(out, want) {
+		t.Errorf("NewRateIterator(%q, %v) = %v -- wanted %v", "1...3", interval, out, want)
+	}
+	it := intlist.NewRateIterator("1", -time.Second)
+	if !errors.Is(it.Err(), intlist.ErrInvalidArg) {
+		t.Errorf("NewRateIterator(%q, %v) error = (%v) -- wanted (%v)",
+			"1", -time.Second, it.Err(), intlist.ErrInvalidArg)
+	}
+}
+
+func TestNextCtx(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	it := intlist.NewIterator("1,2")
+	if _, err := it.NextCtx(ctx); err != context.Canceled {
+		t.Errorf("NextCtx(canceled) error = (%v) -- wanted (%v)", err, context.Canceled)
+	}
+	// A blocked iteration is abandoned and can be continued.
+	tick := make(chan time.Time, 1)
+	it = intlist.NewPacedIterator("1,2", tick)
+	ctx, cancel = context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := it.NextCtx(ctx); err != context.DeadlineExceeded {
+		t.Errorf("NextCtx(timed out) error = (%v) -- wanted (%v)", err, context.DeadlineExceeded)
+	}
+	tick <- time.Time{}
+	if val, err := it.NextCtx(context.Background()); val != 1 || err != nil {
+		t.Errorf("NextCtx() after tick = (%d), (%v) -- wanted (1), (nil)", val, err)
+	}
+}
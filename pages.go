@@ -0,0 +1,36 @@
+// Copyright 2020 Brian E. Holland. All rights reserved.
+// The use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package intlist
+
+// ResolvePages returns the pages selected by the passed specification for a
+// document with total pages, as used by print dialogs.
+//
+// Pages are numbered from 1 and the keyword "N" stands for the last page. The
+// order of the specification is kept along with any repeats.
+//
+//   ResolvePages("1,5...N", 7) -> [1 5 6 7], nil
+//   ResolvePages("N...6,2", 7) -> [7 6 2], nil
+//
+// Potential errors returned:
+//
+//   strconv.ErrSyntax - Error parsing integer or sequence notation
+//   strconv.ErrRange - Integer out of range
+//   ErrOutOfBounds - Page 0 or past the end of the document
+func ResolvePages(spec string, total int) ([]int, error) {
+	c := &config{
+		features: extensions,
+		bounded:  true,
+		lo:       1,
+		hi:       total,
+		word: func(w string) (int, bool) {
+			return total, w == "N"
+		},
+	}
+	seqs, err := parseSpec("ResolvePages", spec, c)
+	if err != nil {
+		return nil, err
+	}
+	return (&Iterator{seqs: seqs}).rest(), nil
+}
@@ -0,0 +1,38 @@
+// Copyright 2020 Brian E. Holland. All rights reserved.
+// The use of this source code is governed by an MIT license
+// that can be found in the LICENSE file.
+
+package intlist_test
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/brianholland99/intlist"
+	"github.com/google/go-cmp/cmp"
+)
+
+var resolvePagesTests = []resolveTest{
+	// Good cases
+	{"1,5...N", 7, []int{1, 5, 6, 7}, nil}, // Through the last page
+	{"N...5,N", 7, []int{7, 6, 5, 7}, nil}, // Reversed with repeat
+	{"N", 1, []int{1}, nil},                // Single page
+	{"", 7, []int{}, nil},                  // No pages
+	// Error cases
+	{"0", 7, nil, intlist.ErrOutOfBounds},     // No page 0
+	{"5...8", 7, nil, intlist.ErrOutOfBounds}, // Past the end
+	{"1", 0, nil, intlist.ErrOutOfBounds},     // Empty document
+	{"N-1", 7, nil, strconv.ErrSyntax},        // No arithmetic
+	{"n", 7, nil, strconv.ErrSyntax},          // Keyword is upper case
+}
+
+func TestResolvePages(t *testing.T) {
+	for _, test := range resolvePagesTests {
+		out, err := intlist.ResolvePages(test.in, test.n)
+		if !cmp.Equal(out, test.out) || !errors.Is(err, test.err) {
+			t.Errorf("ResolvePages(%q, %d) = (%v), (%v) -- wanted (%v), (%v)",
+				test.in, test.n, out, err, test.out, test.err)
+		}
+	}
+}
@@ -0,0 +1,165 @@
+// Copyright 2020 Brian E. Holland. All rights reserved.
+// The use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package intlist
+
+// Pair is two values generated together by a PairIterator.
+type Pair struct {
+	A, B int
+}
+
+// PairIterator is the state for generating pairs of integers from intlist
+// descriptions.
+type PairIterator struct {
+	gen  func() (Pair, bool) // Source of pairs
+	err  error               // Error in creating, if any
+	done bool                // Next has returned ErrDone
+}
+
+// Pairwise validates the specification and sets the state for iterating
+// through the adjacent pairs of values of its expansion, so gaps between
+// consecutive values can be computed without building a slice.
+//
+//   Pairwise("1...3,10") -> [{1 2} {2 3} {3 10}]
+//
+// Potential errors set in state during creation of a PairIterator:
+//
+//   strconv.ErrSyntax - Error parsing integer or sequence notation
+//   strconv.ErrRange - Integer out of range
+func Pairwise(spec string) *PairIterator {
+	seqs, err := parseSeqs("Pairwise", spec)
+	if err != nil {
+		return &PairIterator{err: err}
+	}
+	src := &Iterator{seqs: seqs}
+	prev, err := src.Next()
+	return &PairIterator{
+		gen: func() (Pair, bool) {
+			if err == ErrDone {
+				return Pair{}, false
+			}
+			var val int
+			val, err = src.Next()
+			if err == ErrDone {
+				return Pair{}, false
+			}
+			p := Pair{A: prev, B: val}
+			prev = val
+			return p, true
+		},
+	}
+}
+
+// Zip validates the specifications and sets the state for iterating through
+// pairs of their values in lockstep, stopping at the end of the shorter
+// expansion. This builds mappings between parallel ranges of IDs.
+//
+//   Zip("100...102", "500...599") -> [{100 500} {101 501} {102 502}]
+//
+// Potential errors set in state during creation of a PairIterator:
+//
+//   strconv.ErrSyntax - Error parsing integer or sequence notation
+//   strconv.ErrRange - Integer out of range
+func Zip(a, b string) *PairIterator {
+	aSeqs, err := parseSeqs("Zip", a)
+	if err != nil {
+		return &PairIterator{err: err}
+	}
+	bSeqs, err := parseSeqs("Zip", b)
+	if err != nil {
+		return &PairIterator{err: err}
+	}
+	aIt, bIt := &Iterator{seqs: aSeqs}, &Iterator{seqs: bSeqs}
+	return &PairIterator{
+		gen: func() (Pair, bool) {
+			x, err := aIt.Next()
+			if err == ErrDone {
+				return Pair{}, false
+			}
+			y, err := bIt.Next()
+			if err == ErrDone {
+				return Pair{}, false
+			}
+			return Pair{A: x, B: y}, true
+		},
+	}
+}
+
+// Product validates the specifications and sets the state for iterating
+// through every pair of a value of a with a value of b. Pairs are ordered by
+// the value of a and then by the value of b, as with nested loops, and are
+// generated as they are retrieved.
+//
+//   Product("1,2", "7...9") -> [{1 7} {1 8} {1 9} {2 7} {2 8} {2 9}]
+//
+// Potential errors set in state during creation of a PairIterator:
+//
+//   strconv.ErrSyntax - Error parsing integer or sequence notation
+//   strconv.ErrRange - Integer out of range
+func Product(a, b string) *PairIterator {
+	aSeqs, err := parseSeqs("Product", a)
+	if err != nil {
+		return &PairIterator{err: err}
+	}
+	bSeqs, err := parseSeqs("Product", b)
+	if err != nil {
+		return &PairIterator{err: err}
+	}
+	aIt := &Iterator{seqs: aSeqs}
+	bIt := &Iterator{done: true} // Restarted for each value of a
+	var x int                    // Current value of a
+	return &PairIterator{
+		gen: func() (Pair, bool) {
+			for {
+				if !bIt.done {
+					if y, err := bIt.Next(); err == nil {
+						return Pair{A: x, B: y}, true
+					}
+				}
+				if len(bSeqs) == 0 {
+					return Pair{}, false
+				}
+				var err error
+				if x, err = aIt.Next(); err == ErrDone {
+					return Pair{}, false
+				}
+				// Iteration consumes its sequences, so start from a copy.
+				bIt = &Iterator{seqs: append([]seq(nil), bSeqs...)}
+			}
+		},
+	}
+}
+
+// Next returns the next pair if not done and an error to indicate if done.
+//
+// If ErrDone is returned the pair is not valid and there are no more pairs.
+//
+// It will panic for the following avoidable cases:
+//   - Next called on invalid iterator.
+//   - Next called after previous call to Next() returned ErrDone.
+func (p *PairIterator) Next() (Pair, error) {
+	if p.err != nil {
+		panic("Next() called on invalid iterator.")
+	}
+	if p.done {
+		// Caller was already informed that iterator was done.
+		panic("Next() called again after returning ErrDone.")
+	}
+	pair, ok := p.gen()
+	if !ok {
+		p.done = true
+		return Pair{}, ErrDone
+	}
+	return pair, nil
+}
+
+// Err returns any error that occured when creating this PairIterator.
+func (p *PairIterator) Err() error {
+	return p.err
+}
+
+// Done reports whether a previous Next call returned ErrDone.
+func (p *PairIterator) Done() bool {
+	return p.done
+}
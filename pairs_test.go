@@ -0,0 +1,125 @@
+// Copyright 2020 Brian E. Holland. All rights reserved.
+// The use of this source code is governed by an MIT license
+// that can be found in the LICENSE file.
+
+package intlist_test
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/brianholland99/intlist"
+	"github.com/google/go-cmp/cmp"
+)
+
+// collectPairs returns the remaining pairs of a valid PairIterator.
+func collectPairs(it *intlist.PairIterator) []intlist.Pair {
+	out := []intlist.Pair{}
+	for {
+		p, err := it.Next()
+		if err == intlist.ErrDone {
+			return out
+		}
+		out = append(out, p)
+	}
+}
+
+type pairTest struct {
+	in  string
+	out []intlist.Pair
+	err error
+}
+
+// checkPairs runs the tests of a function creating a PairIterator.
+func checkPairs(t *testing.T, name string, fn func(string) *intlist.PairIterator,
+	tests []pairTest) {
+	t.Helper()
+	for _, test := range tests {
+		it := fn(test.in)
+		if !errors.Is(it.Err(), test.err) {
+			t.Errorf("%s(%q) error = (%v) -- wanted (%v)", name, test.in, it.Err(), test.err)
+			continue
+		}
+		if test.err != nil {
+			continue
+		}
+		if out := collectPairs(it); !cmp.Equal(out, test.out) || !it.Done() {
+			t.Errorf("%s(%q) = %v, done %v -- wanted %v, done true",
+				name, test.in, out, it.Done(), test.out)
+		}
+	}
+}
+
+var pairwiseTests = []pairTest{
+	// Good cases
+	{"1...3,10", []intlist.Pair{{1, 2}, {2, 3}, {3, 10}}, nil},
+	{"5,5", []intlist.Pair{{5, 5}}, nil},
+	{"7", []intlist.Pair{}, nil}, // One value
+	{"", []intlist.Pair{}, nil},  // Empty list
+	// Error cases
+	{"1..3", nil, strconv.ErrSyntax},
+}
+
+func TestPairwise(t *testing.T) {
+	checkPairs(t, "Pairwise", intlist.Pairwise, pairwiseTests)
+}
+
+type twoSpecTest struct {
+	a, b string
+	out  []intlist.Pair
+	err  error
+}
+
+var zipTests = []twoSpecTest{
+	// Good cases
+	{"100...102", "500...599", []intlist.Pair{{100, 500}, {101, 501}, {102, 502}}, nil},
+	{"3...1", "7,8", []intlist.Pair{{3, 7}, {2, 8}}, nil}, // Second is shorter
+	{"", "1...9", []intlist.Pair{}, nil},
+	// Error cases
+	{"1..3", "1", nil, strconv.ErrSyntax},
+	{"1", "1..3", nil, strconv.ErrSyntax},
+}
+
+func TestZip(t *testing.T) {
+	for _, test := range zipTests {
+		it := intlist.Zip(test.a, test.b)
+		if !errors.Is(it.Err(), test.err) {
+			t.Errorf("Zip(%q, %q) error = (%v) -- wanted (%v)", test.a, test.b, it.Err(), test.err)
+			continue
+		}
+		if test.err != nil {
+			continue
+		}
+		if out := collectPairs(it); !cmp.Equal(out, test.out) {
+			t.Errorf("Zip(%q, %q) = %v -- wanted %v", test.a, test.b, out, test.out)
+		}
+	}
+}
+
+var productTests = []twoSpecTest{
+	// Good cases
+	{"1,2", "7...9", []intlist.Pair{{1, 7}, {1, 8}, {1, 9}, {2, 7}, {2, 8}, {2, 9}}, nil},
+	{"5", "2...1", []intlist.Pair{{5, 2}, {5, 1}}, nil},
+	{"1...3", "", []intlist.Pair{}, nil},
+	{"", "1...3", []intlist.Pair{}, nil},
+	// Error cases
+	{"1..3", "1", nil, strconv.ErrSyntax},
+	{"1", "1..3", nil, strconv.ErrSyntax},
+}
+
+func TestProduct(t *testing.T) {
+	for _, test := range productTests {
+		it := intlist.Product(test.a, test.b)
+		if !errors.Is(it.Err(), test.err) {
+			t.Errorf("Product(%q, %q) error = (%v) -- wanted (%v)", test.a, test.b, it.Err(), test.err)
+			continue
+		}
+		if test.err != nil {
+			continue
+		}
+		if out := collectPairs(it); !cmp.Equal(out, test.out) {
+			t.Errorf("Product(%q, %q) = %v -- wanted %v", test.a, test.b, out, test.out)
+		}
+	}
+}
@@ -5,7 +5,10 @@
 package intlist
 
 import (
+	"context"
+	"encoding/binary"
 	"errors"
+	"sort"
 	"strconv"
 	"strings"
 )
@@ -13,26 +16,61 @@ import (
 // ErrDone is returned when iteration when completed.
 var ErrDone = errors.New("no more items in iterator")
 
+// ErrInvalidArg is returned when an argument other than the specification is
+// not acceptable. (E.g., a negative sample size)
+var ErrInvalidArg = errors.New("invalid argument")
+
+// Limits of an int on this platform.
+const (
+	maxInt = 1<<(strconv.IntSize-1) - 1
+	minInt = -maxInt - 1
+)
+
 // Seq is used to denote both single integers and sequences of integers. A
 // single integer is denoted by next == last.
 type seq struct {
 	next int // Next value to retrieve
 	last int // Last value in sequence
-	step int // Direction (I.e., +1 for increasing, -1 for decreasing)
+	step int // Signed stride (I.e., positive for increasing, negative for decreasing, 0 single)
+	reps int // Extra copies of a single value (E.g., 2 for "7x3")
 }
 
 // Iterator is the state for generating integers from an intlist description.
 type Iterator struct {
-	seqs []seq // Remaining sequences to handle
-	err  error // Error in creating or ErrDone if Iterator finishes.
+	seqs []seq              // Remaining sequences to handle
+	gen  func() (int, bool) // Source of values instead of seqs, if not nil
+	err  error              // Error in creating, if any
+	done bool               // Next has returned ErrDone
+
+	count  int                         // Number of values returned
+	total  int                         // Number of values in all if counted
+	sized  bool                        // Whether total has been computed
+	every  int                         // Values between calls of report if above 0
+	report func(done, total int)       // Called every "every" values if not nil
+	pause  func(context.Context) error // Called before each value of seqs if not nil
+
+	noPanic  bool             // Return errors instead of panicking on misuse
+	binWidth int              // Bytes per value for WriteTo if above 0
+	binOrder binary.ByteOrder // Byte order for WriteTo if not nil
+}
+
+// Iter is the behavior shared by every source of ints in this package, so code
+// can accept values from a specification or from a slice made elsewhere (See
+// NewSliceIterator) alike. *Iterator implements it.
+type Iter interface {
+	Next() (int, error) // Next value or ErrDone
+	Done() bool         // Whether Next has returned ErrDone
+	Err() error         // Error in creating, if any
 }
 
 // NewIterator validates the specification and sets the state for iteration.
 //
 // The "spec" parameter is parsed as a string containing a comma-separated list
 // of integers and integer sequences. Sequences are defined by two integers
-// separated by an ellipsis (E.g., "3...100") and include both endpoints. See
-// overall documentation for a more detailed definition of the format.
+// separated by an ellipsis (E.g., "3...100") and include both endpoints.
+// Syntax extensions, such as steps ("0...100:5"), are rejected with
+// ErrFeature (See WithFeatures). See overall documentation for a more detailed
+// definition of the format.
 //
 //   NewIterator("1,2,21,50...54,57...61") ->
 //       [1 2 21 50 51 52 53 54 57 58 59 60 61]
@@ -41,56 +79,289 @@ type Iterator struct {
 //
 //   strconv.ErrSyntax - Error parsing integer or sequence notation
 //   strconv.ErrRange - Integer out of range
+//   ErrFeature - Syntax extension used
 func NewIterator(spec string) *Iterator {
-	var err error  // First error encountered, if any
-	var seqs []seq // Sequences built during parsing
-	const fnNewIterator = "NewIterator"
-	items := strings.Split(spec, ",") // Break into comma-separated items
+	seqs, err := parseSpec("NewIterator", spec, &config{})
+	return &Iterator{
+		seqs: seqs,
+		err:  err,
+	}
+}
+
+// parseSeqs parses spec into its sequences using the default configuration
+// with every syntax extension enabled. The "fn" parameter is the name of the
+// calling function used in any error returned.
+func parseSeqs(fn, spec string) ([]seq, error) {
+	return parseSpec(fn, spec, &config{features: extensions})
+}
+
+// parseSpec parses spec into its sequences using the configuration c. The "fn"
+// parameter is the name of the calling function used in any error returned.
+func parseSpec(fn, spec string, c *config) ([]seq, error) {
+	seqs, _, err := parseItems(fn, spec, c)
+	return seqs, err
+}
+
+// parseItems is parseSpec also returning the index (origin 0) of the item of
+// each sequence.
+func parseItems(fn, spec string, c *config) ([]seq, []int, error) {
+	var err error         // First error encountered, if any
+	var seqs []seq        // Sequences built during parsing
+	var seqIdx []int      // Index of the item of each sequence
+	var excluded []span   // Values of the exclusion items
+	var excludedIdx []int // Index of the item of each of excluded
+
+	if err = c.checkSpec(fn, spec); err != nil {
+		return nil, nil, err
+	}
+	items, offs := c.split(spec) // Break into comma-separated items
 	if len(items) == 1 && items[0] == "" {
 		seqs = []seq{} // Handle empty list case
 	} else {
 		// Handle non-empty list case
-		for _, item := range items {
+		if err = c.checkItems(fn, items); err != nil {
+			return nil, nil, c.annotate(err, spec, items[c.maxItems], offs[c.maxItems])
+		}
+		for i, item := range items {
 			var itemData seq
-			parts := strings.Split(item, "...")
+			body, excluding := c.exclusion(item) // Item without any "!"
+			parts := c.rangeParts(body)
 			switch len(parts) {
 			// First error encountered will be handled after switch.
-			case 1: // Single value (E.g., "265")
+			case 1: // Single value (E.g., "265"), possibly repeated ("7x3")
 				// Treat as sequence of one to simplify iteration routine.
-				itemData.next, err = strconv.Atoi(parts[0])
+				tok := parts[0]
+				if v, times, ok := c.repetition(tok); ok {
+					if !c.features.Has(FeatureRepeat) {
+						err = featureError(fn, item)
+						break
+					}
+					c.used |= FeatureRepeat
+					if itemData.reps, err = repeats(fn, item, times); err != nil {
+						break
+					}
+					tok = v
+				}
+				itemData.next, err = c.value(fn, tok)
 				itemData.last = itemData.next
-			case 2: // Sequence
-				itemData.next, err = strconv.Atoi(parts[0])
+			case 2: // Sequence with an optional step (E.g., "0...100:5")
+				end, stepText, stepped := strings.Cut(parts[1], ":")
+				stride := 1
+				if stepped {
+					if !c.features.Has(FeatureStep) {
+						err = featureError(fn, item)
+						break
+					}
+					c.used |= FeatureStep
+					if stride, err = c.step(fn, item, stepText); err != nil {
+						break
+					}
+				}
+				itemData.next, err = c.endpoint(fn, parts[0], c.openLo)
 				if err != nil {
 					break
 				}
-				itemData.last, err = strconv.Atoi(parts[1])
+				itemData.last, err = c.endpoint(fn, end, c.openHi)
 				if err != nil {
 					break
 				}
+				c.used |= FeatureRange // As written, even if "a...a"
 				if itemData.next < itemData.last {
-					itemData.step = 1 // Increasing sequence
-				} else {
-					itemData.step = -1 // Decreasing sequence
+					itemData.step = stride // Increasing sequence
+				} else if itemData.next > itemData.last {
+					itemData.step = -stride // Decreasing sequence
+					c.used |= FeatureDescending
 				}
-			default: // Multiple "..." in an item
+			default: // Multiple separators (E.g., "...") in an item
 				err = &strconv.NumError{
-					Func: fnNewIterator,
+					Func: fn,
 					Num:  item,
 					Err:  strconv.ErrSyntax,
 				}
 			}
+			if err == nil && excluding {
+				if !c.features.Has(FeatureExclusion) {
+					err = featureError(fn, item)
+				} else {
+					c.used |= FeatureExclusion
+				}
+			}
+			var pieces []seq // Sequences for the item
+			if err == nil {
+				pieces, err = c.wrap(fn, item, itemData)
+			}
+			for k := range pieces {
+				pieces[k] = pieces[k].trim()
+			}
+			traceLast := itemData.last // Last value of the item
+			if len(pieces) > 0 {
+				traceLast = pieces[len(pieces)-1].last
+			}
+			for _, piece := range pieces {
+				if err == nil && !excluding {
+					err = c.checkItem(fn, item, piece)
+				}
+			}
+			if err != nil {
+				err = c.annotate(err, spec, item, offs[i])
+			}
+			if c.trace != nil {
+				c.trace(TraceEvent{
+					Index:  i,
+					Item:   item,
+					Offset: offs[i],
+					First:  itemData.next,
+					Last:   traceLast,
+					Err:    err,
+				})
+			}
 			if err != nil {
-				seqs = nil
 				break
 			}
-			seqs = append(seqs, itemData)
+			if excluding {
+				for _, sp := range seqSpans(pieces) {
+					excluded = append(excluded, sp)
+					excludedIdx = append(excludedIdx, i)
+				}
+				continue
+			}
+			for range pieces {
+				seqIdx = append(seqIdx, i)
+			}
+			seqs = append(seqs, pieces...)
+		}
+		if len(excluded) > 0 {
+			var k int // Index in excluded leaving too many values
+			var ok bool
+			if seqs, seqIdx, k, ok = c.excludeSeqs(seqs, seqIdx, excluded); !ok && err == nil {
+				item := items[excludedIdx[k]]
+				err = c.annotate(&strconv.NumError{Func: fn, Num: item, Err: ErrLimit},
+					spec, item, offs[excludedIdx[k]])
+			}
+		}
+		if err == nil {
+			seqItems := make([]string, len(seqs)) // Item of each sequence
+			for k, i := range seqIdx {
+				seqItems[k] = items[i]
+			}
+			var valid int // Number of sequences passing the checks
+			if valid, err = c.checkSeqs(fn, seqItems, seqs); err != nil {
+				err = c.annotate(err, spec, seqItems[valid], offs[seqIdx[valid]])
+				seqs, seqIdx = seqs[:valid], seqIdx[:valid]
+			}
 		}
 	}
-	return &Iterator{
-		seqs: seqs,
-		err:  err,
+	if err != nil {
+		if c.partial {
+			return seqs, seqIdx, err // Sequences of the items before the failure
+		}
+		return nil, nil, err
+	}
+	return seqs, seqIdx, nil
+}
+
+// len returns the number of values in s or 0 if that overflows a uint64. The
+// only sequence that overflows is one covering every int on a 64-bit platform.
+func (s seq) len() uint64 {
+	switch {
+	case s.step > 0:
+		return (uint64(s.last)-uint64(s.next))/uint64(s.step) + 1
+	case s.step < 0:
+		return (uint64(s.next)-uint64(s.last))/(-uint64(s.step)) + 1
 	}
+	return uint64(s.reps) + 1
+}
+
+// advance moves s past its next value, reporting false if that was the last.
+func (s *seq) advance() bool {
+	switch {
+	case s.next != s.last:
+		s.next += s.step
+	case s.reps > 0:
+		s.reps--
+	default:
+		return false
+	}
+	return true
+}
+
+// reversed returns s producing its values backwards.
+func (s seq) reversed() seq {
+	s.next, s.last, s.step = s.last, s.next, -s.step
+	return s
+}
+
+// trim returns s with last moved to the final value reached from next by the
+// step, which need not be last itself when the stride is above one (E.g.,
+// "0...10:3" ends at 9).
+func (s seq) trim() seq {
+	if s.step == 0 || s.step == 1 || s.step == -1 {
+		return s
+	}
+	s.last = s.at(s.len() - 1)
+	if s.next == s.last {
+		s.step = 0
+	}
+	return s
+}
+
+// bounds returns the smallest and largest values of s.
+func (s seq) bounds() (lo, hi int) {
+	if s.step < 0 {
+		return s.last, s.next
+	}
+	return s.next, s.last
+}
+
+// contains reports whether v is one of the values of s.
+func (s seq) contains(v int) bool {
+	lo, hi := s.bounds()
+	switch {
+	case v < lo || v > hi:
+		return false
+	case s.step > 0:
+		return (uint64(v)-uint64(s.next))%uint64(s.step) == 0
+	case s.step < 0:
+		return (uint64(s.next)-uint64(v))%(-uint64(s.step)) == 0
+	}
+	return true
+}
+
+// at returns the k-th value (origin 0) of s. The "k" parameter must be less
+// than s.len().
+func (s seq) at(k uint64) int {
+	return int(uint64(s.next) + k*uint64(s.step))
+}
+
+// cumLens returns the running totals of the number of values in seqs. The
+// i-th entry is the number of values in seqs[:i+1]. The "fn" and "spec"
+// parameters are used to report strconv.ErrRange if the total overflows.
+func cumLens(fn, spec string, seqs []seq) ([]uint64, error) {
+	cum := make([]uint64, len(seqs))
+	var total uint64
+	for i, s := range seqs {
+		n := s.len()
+		if n == 0 || total+n < total {
+			return nil, &strconv.NumError{
+				Func: fn,
+				Num:  spec,
+				Err:  strconv.ErrRange,
+			}
+		}
+		total += n
+		cum[i] = total
+	}
+	return cum, nil
+}
+
+// valueAt returns the value at position idx (origin 0) of the expansion of
+// seqs where cum is the result of cumLens for seqs.
+func valueAt(seqs []seq, cum []uint64, idx uint64) int {
+	i := sort.Search(len(cum), func(i int) bool { return cum[i] > idx })
+	if i > 0 {
+		idx -= cum[i-1]
+	}
+	return seqs[i].at(idx)
 }
 
 // Next returns the next integer if not done and an error to indicate if done.
@@ -101,41 +372,108 @@ func NewIterator(spec string) *Iterator {
 //   - Next called on invalid iterator.
 //   - Next called after previous call to Next() returned ErrDone.
 func (i *Iterator) Next() (int, error) {
+	return i.NextCtx(context.Background())
+}
+
+// NextCtx is Next that returns ctx.Err() instead of a value if ctx is done,
+// so a consumer can abandon an iteration that blocks, such as one created by
+// NewPacedIterator, when its request is canceled. The iteration is left as it
+// was and may be continued with another context.
+func (i *Iterator) NextCtx(ctx context.Context) (int, error) {
 	if i.err != nil {
-		if i.err == ErrDone {
-			// Caller was already informed that iterator was done.
-			panic("Next() called again after returning ErrDone.")
+		if i.noPanic {
+			return 0, i.err
 		}
 		panic("Next() called on invalid iterator.")
 	}
-	if len(i.seqs) == 0 {
-		i.err = ErrDone
-		return 0, ErrDone
+	if i.done {
+		if i.noPanic {
+			return 0, ErrDone
+		}
+		// Caller was already informed that iterator was done.
+		panic("Next() called again after returning ErrDone.")
+	}
+	if err := ctx.Err(); err != nil {
+		return 0, err
 	}
-	item := &i.seqs[0] // Current sequence being handled
-	val := item.next
-	if val == item.last {
-		// Done with this item. Remove handled expression.
-		i.seqs = i.seqs[1:]
+	var val int
+	if i.gen != nil {
+		var ok bool
+		if val, ok = i.gen(); !ok {
+			i.done = true
+			return 0, ErrDone
+		}
 	} else {
-		item.next += item.step // Move to next value in sequence.
+		if len(i.seqs) == 0 {
+			i.done = true
+			return 0, ErrDone
+		}
+		if i.pause != nil {
+			if err := i.pause(ctx); err != nil {
+				return 0, err
+			}
+		}
+		item := &i.seqs[0] // Current sequence being handled
+		val = item.next
+		if !item.advance() {
+			// Done with this item. Remove handled expression.
+			i.seqs = i.seqs[1:]
+		}
+	}
+	i.count++
+	if i.report != nil && i.count%i.every == 0 {
+		i.report(i.Progress())
 	}
 	return val, nil
 }
 
-// Err returns any error that occured when creating this Iterator or ErrDone
-// if a previous Next call returned that to indicate that the end of the
-// iteration occurred.
+// Progress returns the number of values returned by Next so far and the
+// number of values in the whole iteration, such as for driving a progress
+// bar. The total is -1 if it is not known in advance, as for iterators that
+// generate values as they go, or does not fit in an int.
+func (i *Iterator) Progress() (done, total int) {
+	if !i.sized {
+		i.total, i.sized = -1, true
+		if i.gen == nil && i.err == nil {
+			remaining := uint64(0)
+			for _, s := range i.seqs {
+				n := s.len()
+				if n == 0 || remaining+n < remaining {
+					remaining = maxInt // Too many to count.
+					break
+				}
+				remaining += n
+			}
+			if remaining <= uint64(maxInt-i.count) {
+				i.total = i.count + int(remaining)
+			}
+		}
+	}
+	return i.count, i.total
+}
+
+// Err returns any error that occured when creating this Iterator.
+//
+// Reaching the end of the iteration is not an error. Use Done to check whether
+// a previous Next call returned ErrDone.
 func (i *Iterator) Err() error {
 	return i.err
 }
 
+// Done reports whether a previous Next call returned ErrDone to indicate that
+// the end of the iteration occurred.
+func (i *Iterator) Done() bool {
+	return i.done
+}
+
 // Parse will return an int slice represented by the passed specification.
 //
 // The "spec" parameter is parsed as containing a comma-separated list of
 // integers and integer sequences. Sequences are defined by two integers
-// separated by an ellipsis (E.g., "3...100") and include both endpoints. See
-// overall documentation for a more detailed definition of the format.
+// separated by an ellipsis (E.g., "3...100") and include both endpoints.
+// Syntax extensions, such as steps ("0...100:5"), are rejected with
+// ErrFeature (See WithFeatures). See overall documentation for a more detailed
+// definition of the format.
 //
 // Parse("1,2,21,50...54,61..57") ->
 //       [1 2 21 50 51 52 53 54 61 60 59 58 57], nil
@@ -144,18 +482,24 @@ func (i *Iterator) Err() error {
 //
 //   strconv.ErrSyntax - Error parsing integer or sequence notation.
 //   strconv.ErrRange - Integer out of range
+//   ErrFeature - Syntax extension used
 func Parse(spec string) ([]int, error) {
-	result := []int{}
 	it := NewIterator(spec)
 	if it.Err() != nil {
 		return nil, it.Err()
 	}
+	return it.rest(), nil
+}
+
+// rest returns the remaining values of the valid Iterator i.
+func (i *Iterator) rest() []int {
+	result := []int{}
 	for {
-		val, err := it.Next()
+		val, err := i.Next()
 		if err == ErrDone {
 			break
 		}
 		result = append(result, val)
 	}
-	return result, nil
+	return result
 }
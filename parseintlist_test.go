@@ -32,6 +32,11 @@ var parseTests = []parseTest{
 	{"3.5,12", nil, strconv.ErrSyntax},          // Non-integer
 	{"3.9...5", nil, strconv.ErrSyntax},         // Seq. start - non-integer
 	{"2...5.4", nil, strconv.ErrSyntax},         // Seq. end - non-integer
+	{"0...20:5", nil, intlist.ErrFeature},       // Step not enabled
+	{"5:2", nil, strconv.ErrSyntax},             // Step of a single value
+	{"0:2...10", nil, strconv.ErrSyntax},        // Step after first endpoint
+	{"7x3,1", nil, intlist.ErrFeature},          // Repetition not enabled
+	{"0x1F", nil, strconv.ErrSyntax},            // Hexadecimal
 }
 
 // This tests Parse and indirectly tests most of the Iterator code.
@@ -45,6 +50,54 @@ func TestParse(t *testing.T) {
 	}
 }
 
+var stepTests = []parseTest{
+	// Good cases
+	{"0...20:5", []int{0, 5, 10, 15, 20}, nil}, // Step
+	{"10...0:4", []int{10, 6, 2}, nil},         // Descending step past end
+	{"3...4:9,5...5:2", []int{3, 5}, nil},      // Step past both ends
+	{"1...3:1", []int{1, 2, 3}, nil},           // Unit step
+	// Error cases
+	{"0...10:0", nil, strconv.ErrSyntax},   // Zero step
+	{"10...0:-2", nil, strconv.ErrSyntax},  // Negative step
+	{"0...10:", nil, strconv.ErrSyntax},    // Missing step
+	{"0...10:2:2", nil, strconv.ErrSyntax}, // Multiple steps
+	{"0:2...10", nil, strconv.ErrSyntax},   // Step after first endpoint
+}
+
+func TestSteps(t *testing.T) {
+	for _, test := range stepTests {
+		out, err := intlist.ParseWithOptions(test.in, intlist.WithFeatures(intlist.FeatureStep))
+		if !cmp.Equal(out, test.out) || !errors.Is(err, test.err) {
+			t.Errorf("ParseWithOptions(%q, WithFeatures(FeatureStep)) = (%v), (%v) -- "+
+				"wanted (%v), (%v)", test.in, out, err, test.out, test.err)
+		}
+	}
+}
+
+var repetitionTests = []parseTest{
+	// Good cases
+	{"7x3,1", []int{7, 7, 7, 1}, nil},                  // Repetition
+	{"0x10", []int{0, 0, 0, 0, 0, 0, 0, 0, 0, 0}, nil}, // Not hexadecimal
+	{"-2x1", []int{-2}, nil},                           // One copy
+	// Error cases
+	{"7x0", nil, strconv.ErrSyntax},     // No copies
+	{"x3", nil, strconv.ErrSyntax},      // Missing value
+	{"7x", nil, strconv.ErrSyntax},      // Missing count
+	{"0x1F", nil, strconv.ErrSyntax},    // Hexadecimal
+	{"1...3x2", nil, strconv.ErrSyntax}, // Repeated sequence
+	{"7x99999999999999999999", nil, strconv.ErrRange},
+}
+
+func TestRepetition(t *testing.T) {
+	for _, test := range repetitionTests {
+		out, err := intlist.ParseWithOptions(test.in, intlist.WithFeatures(intlist.FeatureRepeat))
+		if !cmp.Equal(out, test.out) || !errors.Is(err, test.err) {
+			t.Errorf("ParseWithOptions(%q, WithFeatures(FeatureRepeat)) = (%v), (%v) -- "+
+				"wanted (%v), (%v)", test.in, out, err, test.out, test.err)
+		}
+	}
+}
+
 // The remaining tests check proper response to misuse of Iterator functions
 // by callers and also the proper return of ErrDone by Next().
 
@@ -72,6 +125,11 @@ func TestUseOfNextWithErrDone(t *testing.T) {
 	if err != intlist.ErrDone {
 		t.Errorf("ErrDone not returned on empty list")
 	}
+	// Finishing is reported by Done rather than as an error.
+	if !it.Done() || it.Err() != nil {
+		t.Errorf("Done() = %v, Err() = %v after ErrDone -- wanted true, nil",
+			it.Done(), it.Err())
+	}
 	// Ignore error to see if next call to Next() panics as expected.
 	defer func() {
 		if err := recover(); err == nil {
@@ -86,3 +144,19 @@ func TestUseOfNextWithErrDone(t *testing.T) {
 	}()
 	_, _ = it.Next()
 }
+
+func TestProgress(t *testing.T) {
+	it := intlist.NewIterator("1...3,9")
+	for want := 0; want <= 4; want++ {
+		if done, total := it.Progress(); done != want || total != 4 {
+			t.Errorf("Progress() = (%d), (%d) -- wanted (%d), (4)", done, total, want)
+		}
+		it.Next()
+	}
+	// Values generated as they go have no known total.
+	it = intlist.MapValues("1...3", func(v int) int { return v })
+	it.Next()
+	if done, total := it.Progress(); done != 1 || total != -1 {
+		t.Errorf("MapValues Progress() = (%d), (%d) -- wanted (1), (-1)", done, total)
+	}
+}
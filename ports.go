@@ -0,0 +1,45 @@
+// Copyright 2020 Brian E. Holland. All rights reserved.
+// The use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package intlist
+
+import "net"
+
+// ParsePorts returns the TCP ports represented by the passed specification,
+// where a service name may be used in place of any port number.
+//
+// Names are looked up in services, or with net.LookupPort for TCP if services
+// is nil. Ports must be in the range 0 through 65535. The order of the
+// specification is kept along with any repeats.
+//
+//   ParsePorts("http,443,8000...8002", nil) -> [80 443 8000 8001 8002], nil
+//   ParsePorts("admin,22", map[string]int{"admin": 8443}) -> [8443 22], nil
+//
+// Potential errors returned:
+//
+//   strconv.ErrSyntax - Error parsing integer, service name or sequence
+//       notation
+//   strconv.ErrRange - Integer out of range
+//   ErrOutOfBounds - Port outside the range 0 through 65535
+func ParsePorts(spec string, services map[string]int) ([]int, error) {
+	c := &config{
+		features: extensions,
+		bounded:  true,
+		lo:       0,
+		hi:       65535,
+		word: func(name string) (int, bool) {
+			if services != nil {
+				port, ok := services[name]
+				return port, ok
+			}
+			port, err := net.LookupPort("tcp", name)
+			return port, err == nil
+		},
+	}
+	seqs, err := parseSpec("ParsePorts", spec, c)
+	if err != nil {
+		return nil, err
+	}
+	return (&Iterator{seqs: seqs}).rest(), nil
+}
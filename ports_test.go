@@ -0,0 +1,48 @@
+// Copyright 2020 Brian E. Holland. All rights reserved.
+// The use of this source code is governed by an MIT license
+// that can be found in the LICENSE file.
+
+package intlist_test
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/brianholland99/intlist"
+	"github.com/google/go-cmp/cmp"
+)
+
+var services = map[string]int{"web": 8080, "admin": 8443, "huge": 70000, "x11": 6000,
+	"box2": 9000}
+
+var parsePortsTests = []struct {
+	in       string
+	services map[string]int
+	out      []int
+	err      error
+}{
+	// Good cases
+	{"http,443,8000...8002", nil, []int{80, 443, 8000, 8001, 8002}, nil},
+	{"admin,22", services, []int{8443, 22}, nil},
+	{"web...8082", services, []int{8080, 8081, 8082}, nil}, // Name as endpoint
+	{"0,65535", services, []int{0, 65535}, nil},            // Limits
+	{"box2,22x2", services, []int{9000, 22, 22}, nil},      // Name ending like a repetition
+	{"", services, []int{}, nil},
+	// Error cases
+	{"http", services, nil, strconv.ErrSyntax}, // Table replaces lookup
+	{"nosuch", nil, nil, strconv.ErrSyntax},    // Unknown service
+	{"65536", services, nil, intlist.ErrOutOfBounds},
+	{"huge", services, nil, intlist.ErrOutOfBounds}, // Names are checked too
+	{"-1...1", services, nil, intlist.ErrOutOfBounds},
+}
+
+func TestParsePorts(t *testing.T) {
+	for _, test := range parsePortsTests {
+		out, err := intlist.ParsePorts(test.in, test.services)
+		if !cmp.Equal(out, test.out) || !errors.Is(err, test.err) {
+			t.Errorf("ParsePorts(%q, %v) = (%v), (%v) -- wanted (%v), (%v)",
+				test.in, test.services, out, err, test.out, test.err)
+		}
+	}
+}
@@ -0,0 +1,468 @@
+// Copyright 2020 Brian E. Holland. All rights reserved.
+// The use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package intlist
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+// ErrNotFound is returned when a query has no value to return.
+var ErrNotFound = errors.New("no such value")
+
+// Summary describes a specification without expanding it.
+type Summary struct {
+	Items      int     // Number of comma-separated items
+	Values     uint64  // Number of values in the expansion
+	Min        int     // Smallest value if Values > 0
+	Max        int     // Largest value if Values > 0
+	Descending int     // Number of decreasing items, not counting exclusions
+	Density    float64 // Values divided by the span from Min to Max
+}
+
+// Describe returns a Summary of the passed specification computed from its
+// items, so admin tools can show something like "3 ranges, 1,024 values, span
+// 1–5,000" for any size of specification. Density is more than 1 when values
+// are repeated and 0 for an empty specification.
+//
+//   Describe("1...10,30...21,50") -> Summary{Items: 3, Values: 21, Min: 1,
+//       Max: 50, Descending: 1, Density: 0.42}, nil
+//
+// Potential errors returned:
+//
+//   strconv.ErrSyntax - Error parsing integer or sequence notation
+//   strconv.ErrRange - Integer out of range or too many values to count
+func Describe(spec string) (Summary, error) {
+	const fn = "Describe"
+	seqs, err := parseSeqs(fn, spec)
+	if err != nil {
+		return Summary{}, err
+	}
+	cum, err := cumLens(fn, spec, seqs)
+	if err != nil {
+		return Summary{}, err
+	}
+	sum := Summary{}
+	if spec != "" {
+		items, _ := (&config{}).split(spec)
+		sum.Items = len(items) // Including any exclusions
+		for _, item := range items {
+			if _, excluding := (&config{}).exclusion(item); excluding {
+				continue
+			}
+			// Exclusions may split an item, so its own sequences tell.
+			if own, _ := parseSeqs(fn, item); len(own) > 0 && own[0].step < 0 {
+				sum.Descending++
+			}
+		}
+	}
+	for i, s := range seqs {
+		lo, hi := s.bounds()
+		if i == 0 || lo < sum.Min {
+			sum.Min = lo
+		}
+		if i == 0 || hi > sum.Max {
+			sum.Max = hi
+		}
+	}
+	if len(cum) > 0 {
+		sum.Values = cum[len(cum)-1]
+		width := float64(uint64(sum.Max)-uint64(sum.Min)) + 1
+		sum.Density = float64(sum.Values) / width
+	}
+	return sum, nil
+}
+
+// Count returns the number of values in the expansion of the passed
+// specification, including any repeats, computed from its items. This suits
+// pre-sizing buffers and progress bars for any size of specification.
+//
+//   Count("1...1000000000,5,0...100:10") -> 1000000012, nil
+//
+// Potential errors returned:
+//
+//   strconv.ErrSyntax - Error parsing integer or sequence notation
+//   strconv.ErrRange - Integer out of range or too many values to count
+//   ErrLimit - Exclusion with a step leaving too many sequences
+func Count(spec string) (uint64, error) {
+	const fn = "Count"
+	seqs, err := parseSeqs(fn, spec)
+	if err != nil {
+		return 0, err
+	}
+	cum, err := cumLens(fn, spec, seqs)
+	if err != nil || len(cum) == 0 {
+		return 0, err
+	}
+	return cum[len(cum)-1], nil
+}
+
+// Bounds returns the smallest and largest values of the passed specification
+// computed from the endpoints of its items, so a specification can be checked
+// against an allowed window before any work begins.
+//
+//   Bounds("20...30,500,10...1") -> 1, 500, nil
+//   Bounds("") -> 0, 0, ErrNotFound
+//
+// Potential errors returned:
+//
+//   strconv.ErrSyntax - Error parsing integer or sequence notation
+//   strconv.ErrRange - Integer out of range
+//   ErrNotFound - The specification has no values
+func Bounds(spec string) (min, max int, err error) {
+	const fn = "Bounds"
+	seqs, err := parseSeqs(fn, spec)
+	if err != nil {
+		return 0, 0, err
+	}
+	if len(seqs) == 0 {
+		return 0, 0, fmt.Errorf("intlist.%s: empty specification: %w", fn, ErrNotFound)
+	}
+	for i, s := range seqs {
+		lo, hi := s.bounds()
+		if i == 0 || lo < min {
+			min = lo
+		}
+		if i == 0 || hi > max {
+			max = hi
+		}
+	}
+	return min, max, nil
+}
+
+// Rank returns the number of distinct values of the passed specification that
+// are less than or equal to x. It is computed from the sequences, so the cost
+// does not depend on the number of values.
+//
+//   Rank("1...10,5...15,30", 12) -> 12, nil
+//
+// Potential errors returned:
+//
+//   strconv.ErrSyntax - Error parsing integer or sequence notation
+//   strconv.ErrRange - Integer out of range or a count too large for an int
+func Rank(spec string, x int) (int, error) {
+	const fn = "Rank"
+	seqs, err := parseSeqs(fn, spec)
+	if err != nil {
+		return 0, err
+	}
+	var count uint64
+	for _, sp := range normalize(seqSpans(seqs)) {
+		if sp.lo > x {
+			break
+		}
+		sp, _ = sp.clip(sp.lo, x)
+		n := sp.len()
+		if n == 0 || n > maxInt-count {
+			return 0, &strconv.NumError{Func: fn, Num: spec, Err: strconv.ErrRange}
+		}
+		count += n
+	}
+	return int(count), nil
+}
+
+// Next returns the smallest value of the passed specification that is greater
+// than or equal to x, such as the next free ID in an allowed set.
+//
+//   Next("1...10,20...30", 11) -> 20, nil
+//   Next("1...10,20...30", 31) -> 0, ErrNotFound
+//
+// Potential errors returned:
+//
+//   strconv.ErrSyntax - Error parsing integer or sequence notation
+//   strconv.ErrRange - Integer out of range
+//   ErrNotFound - No value is greater than or equal to x
+func Next(spec string, x int) (int, error) {
+	return nearest("Next", spec, x, seq.ceil, func(a, b int) bool { return a < b })
+}
+
+// Prev returns the largest value of the passed specification that is less
+// than or equal to x.
+//
+//   Prev("1...10,20...30", 15) -> 10, nil
+//   Prev("1...10,20...30", 0) -> 0, ErrNotFound
+//
+// Potential errors returned:
+//
+//   strconv.ErrSyntax - Error parsing integer or sequence notation
+//   strconv.ErrRange - Integer out of range
+//   ErrNotFound - No value is less than or equal to x
+func Prev(spec string, x int) (int, error) {
+	return nearest("Prev", spec, x, seq.floor, func(a, b int) bool { return a > b })
+}
+
+// nearest implements Next and Prev by returning the best by "better" of the
+// values found by "find" for each sequence. The "fn" parameter is the name of
+// the calling function used in any error returned.
+func nearest(fn, spec string, x int, find func(seq, int) (int, bool),
+	better func(a, b int) bool) (int, error) {
+	seqs, err := parseSeqs(fn, spec)
+	if err != nil {
+		return 0, err
+	}
+	best, found := 0, false
+	for _, s := range seqs {
+		if v, ok := find(s, x); ok && (!found || better(v, best)) {
+			best, found = v, true
+		}
+	}
+	if !found {
+		return 0, fmt.Errorf("intlist.%s: %d: %w", fn, x, ErrNotFound)
+	}
+	return best, nil
+}
+
+// ceil returns the smallest value of s that is greater than or equal to x. It
+// reports false if there is none.
+func (s seq) ceil(x int) (int, bool) {
+	lo, hi := s.bounds()
+	switch {
+	case x > hi:
+		return 0, false
+	case x <= lo:
+		return lo, true
+	}
+	// Round the distance from lo up to a multiple of the stride.
+	stride := s.stride()
+	k := (uint64(x)-uint64(lo)-1)/stride + 1
+	return int(uint64(lo) + k*stride), true
+}
+
+// floor returns the largest value of s that is less than or equal to x. It
+// reports false if there is none.
+func (s seq) floor(x int) (int, bool) {
+	lo, hi := s.bounds()
+	switch {
+	case x < lo:
+		return 0, false
+	case x >= hi:
+		return hi, true
+	}
+	// Round the distance from lo down to a multiple of the stride.
+	stride := s.stride()
+	k := (uint64(x) - uint64(lo)) / stride
+	return int(uint64(lo) + k*stride), true
+}
+
+// stride returns the distance between consecutive values of s, which must not
+// be a single value.
+func (s seq) stride() uint64 {
+	if s.step < 0 {
+		return -uint64(s.step)
+	}
+	return uint64(s.step)
+}
+
+// Head returns the first n values of the passed specification, or all of them
+// if there are fewer. Only the sequences holding those values are walked, so
+// it suits previewing enormous specifications.
+//
+//   Head("1...1000000000,5", 3) -> [1 2 3], nil
+//
+// Potential errors returned:
+//
+//   strconv.ErrSyntax - Error parsing integer or sequence notation
+//   strconv.ErrRange - Integer out of range
+//   ErrInvalidArg - The "n" parameter is negative
+func Head(spec string, n int) ([]int, error) {
+	seqs, err := parseEnds("Head", spec, n)
+	if err != nil {
+		return nil, err
+	}
+	return headSeqs(seqs, n), nil
+}
+
+// Tail returns the last n values of the passed specification in their order
+// in the expansion, or all of them if there are fewer. Sequences are walked
+// from the end, so it suits previewing enormous specifications.
+//
+//   Tail("5,1...1000000000", 3) -> [999999998 999999999 1000000000], nil
+//
+// Potential errors returned:
+//
+//   strconv.ErrSyntax - Error parsing integer or sequence notation
+//   strconv.ErrRange - Integer out of range
+//   ErrInvalidArg - The "n" parameter is negative
+func Tail(spec string, n int) ([]int, error) {
+	seqs, err := parseEnds("Tail", spec, n)
+	if err != nil {
+		return nil, err
+	}
+	result := headSeqs(reverseSeqs(seqs), n)
+	for i, j := 0, len(result)-1; i < j; i, j = i+1, j-1 {
+		result[i], result[j] = result[j], result[i]
+	}
+	return result, nil
+}
+
+// parseEnds checks the count n for Head or Tail and parses spec. The "fn"
+// parameter is the name of the calling function used in any error returned.
+func parseEnds(fn, spec string, n int) ([]seq, error) {
+	if n < 0 {
+		return nil, fmt.Errorf("intlist.%s: n = %d: %w", fn, n, ErrInvalidArg)
+	}
+	return parseSeqs(fn, spec)
+}
+
+// headSeqs returns the first n values of the expansion of seqs.
+func headSeqs(seqs []seq, n int) []int {
+	result := []int{}
+	for _, s := range seqs {
+		for k := uint64(0); len(result) < n; k++ {
+			result = append(result, s.at(k))
+			if k+1 == s.len() {
+				break
+			}
+		}
+	}
+	return result
+}
+
+// Covers reports whether every integer from lo through hi is a value of the
+// passed specification, such as checking that assigned ranges leave no holes.
+//
+//   Covers("1...10,11...20,25", 5, 20) -> true, nil
+//   Covers("1...10,12...20", 5, 20) -> false, nil
+//
+// Potential errors returned:
+//
+//   strconv.ErrSyntax - Error parsing integer or sequence notation
+//   strconv.ErrRange - Integer out of range
+//   ErrInvalidArg - lo is greater than hi
+func Covers(spec string, lo, hi int) (bool, error) {
+	seqs, err := parseWindow("Covers", spec, lo, hi)
+	if err != nil {
+		return false, err
+	}
+	return covered(span{lo: lo, hi: hi}, normalize(seqSpans(seqs))), nil
+}
+
+// ItemOf returns the index (origin 0) of the first comma-separated item of the
+// passed specification holding v, so messages can point to the item a value
+// came from.
+//
+//   ItemOf("1...10,20...30,25", 25) -> 1, nil
+//   ItemOf("1...10,20...30", 15) -> 0, ErrNotFound
+//
+// Potential errors returned:
+//
+//   strconv.ErrSyntax - Error parsing integer or sequence notation
+//   strconv.ErrRange - Integer out of range
+//   ErrNotFound - No item holds v
+func ItemOf(spec string, v int) (int, error) {
+	const fn = "ItemOf"
+	seqs, idx, err := parseItems(fn, spec, &config{features: extensions})
+	if err != nil {
+		return 0, err
+	}
+	for i, s := range seqs {
+		if s.contains(v) {
+			return idx[i], nil
+		}
+	}
+	return 0, fmt.Errorf("intlist.%s: %d: %w", fn, v, ErrNotFound)
+}
+
+// Contains reports whether v is a value of the passed specification. Each item
+// is checked from its endpoints, so the cost does not depend on the number of
+// values. Use Compile and List.Contains to check many values against a
+// specification parsed once.
+//
+//   Contains("1...10000000,-5", 4096) -> true, nil
+//   Contains("0...100:5", 12) -> false, nil
+//
+// Potential errors returned:
+//
+//   strconv.ErrSyntax - Error parsing integer or sequence notation
+//   strconv.ErrRange - Integer out of range
+func Contains(spec string, v int) (bool, error) {
+	seqs, err := parseSeqs("Contains", spec)
+	if err != nil {
+		return false, err
+	}
+	return containsValue(seqs, v), nil
+}
+
+// containsValue reports whether v is a value of any of seqs.
+func containsValue(seqs []seq, v int) bool {
+	for _, s := range seqs {
+		if s.contains(v) {
+			return true
+		}
+	}
+	return false
+}
+
+// OverlapRange returns a specification expanding to the values of the passed
+// specification from lo through hi, for processing a large specification one
+// window at a time. The order of the values is kept along with any repeats.
+//
+//   OverlapRange("1...10,30...20,15", 8, 22) -> "8...10,22...20,15", nil
+//
+// Potential errors returned:
+//
+//   strconv.ErrSyntax - Error parsing integer or sequence notation
+//   strconv.ErrRange - Integer out of range
+//   ErrInvalidArg - lo is greater than hi
+func OverlapRange(spec string, lo, hi int) (string, error) {
+	seqs, err := parseWindow("OverlapRange", spec, lo, hi)
+	if err != nil {
+		return "", err
+	}
+	var result []seq
+	for _, s := range seqs {
+		if s, ok := s.within(lo, hi); ok {
+			result = append(result, s)
+		}
+	}
+	return formatSeqs(result), nil
+}
+
+// Overlaps reports whether any value of the passed specification is from lo
+// through hi. It is quicker than checking for an empty OverlapRange.
+//
+//   Overlaps("1...10,30...20", 12, 18) -> false, nil
+//
+// Potential errors returned are those of OverlapRange.
+func Overlaps(spec string, lo, hi int) (bool, error) {
+	seqs, err := parseWindow("Overlaps", spec, lo, hi)
+	if err != nil {
+		return false, err
+	}
+	for _, s := range seqs {
+		if _, ok := s.within(lo, hi); ok {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// parseWindow checks the interval [lo, hi] for a query and parses spec. The "fn"
+// parameter is the name of the calling function used in any error returned.
+func parseWindow(fn, spec string, lo, hi int) ([]seq, error) {
+	if lo > hi {
+		return nil, fmt.Errorf("intlist.%s: interval [%d, %d]: %w", fn, lo, hi, ErrInvalidArg)
+	}
+	return parseSeqs(fn, spec)
+}
+
+// within returns the part of s with values from lo through hi, keeping the
+// order of s. It reports false if no values remain.
+func (s seq) within(lo, hi int) (seq, bool) {
+	first, ok := s.ceil(lo)
+	if !ok || first > hi {
+		return s, false
+	}
+	last, _ := s.floor(hi) // At least first, so found
+	if s.step < 0 {
+		first, last = last, first
+	}
+	s.next, s.last = first, last
+	if first == last {
+		s.step = 0
+	}
+	return s, true
+}
@@ -0,0 +1,47 @@
+// Copyright 2020 Brian E. Holland. All rights reserved.
+// The use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package intlist
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// DefaultMaxQueryValues is the limit on the number of values that FromQuery
+// applies unless another is passed using WithMaxValues.
+const DefaultMaxQueryValues = 10000
+
+// FromQuery parses the query parameter named key (E.g., "ids" for
+// "?ids=1...50,90") into a List. This suits REST services accepting selections
+// of IDs.
+//
+// A parameter given more than once is treated as one specification made by
+// joining the values with commas, skipping empty ones. A missing parameter is
+// an empty specification. The number of values is limited to DefaultMaxQueryValues
+// unless WithMaxValues is passed in "opts", which are applied to the parsing.
+// Use WithBounds to restrict the range of IDs, WithNonEmpty to require the
+// parameter, and so on. The error returned names the parameter, so its
+// message can be passed back to the client.
+//
+//   q, _ := url.ParseQuery("ids=1...3,90")
+//   FromQuery(q, "ids", WithBounds(1, 100)) -> List with [1 2 3 90], nil
+//
+// Potential errors returned wrap the errors of ParseWithOptions.
+func FromQuery(q url.Values, key string, opts ...Option) (*List, error) {
+	all := append([]Option{WithMaxValues(DefaultMaxQueryValues)}, opts...)
+	var values []string // Non-empty values of the parameter
+	for _, v := range q[key] {
+		if v != "" {
+			values = append(values, v)
+		}
+	}
+	spec := strings.Join(values, ",")
+	seqs, err := parseSpec("FromQuery", spec, newConfig(all))
+	if err != nil {
+		return nil, fmt.Errorf("query parameter %q: %w", key, err)
+	}
+	return &List{seqs: seqs}, nil
+}
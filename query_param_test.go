@@ -0,0 +1,65 @@
+// Copyright 2020 Brian E. Holland. All rights reserved.
+// The use of this source code is governed by an MIT license
+// that can be found in the LICENSE file.
+
+package intlist_test
+
+import (
+	"errors"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/brianholland99/intlist"
+	"github.com/google/go-cmp/cmp"
+)
+
+type fromQueryTest struct {
+	query string
+	opts  []intlist.Option
+	out   []int
+	err   error
+}
+
+var fromQueryTests = []fromQueryTest{
+	// Good cases
+	{"ids=1...3,90", nil, []int{1, 2, 3, 90}, nil},   // Mixed
+	{"ids=1...3&ids=7", nil, []int{1, 2, 3, 7}, nil}, // Repeated
+	{"other=5", nil, []int{}, nil},                   // Missing
+	{"ids=&ids=5", nil, []int{5}, nil},               // Empty value skipped
+	{"ids=", nil, []int{}, nil},
+	{"ids=4", []intlist.Option{intlist.WithBounds(1, 9)}, []int{4}, nil},
+	// Error cases
+	{"ids=1...20000", nil, nil, intlist.ErrLimit}, // Default limit
+	{"ids=1...9", []intlist.Option{intlist.WithMaxValues(5)}, nil,
+		intlist.ErrLimit}, // Limit
+	{"ids=0...9", []intlist.Option{intlist.WithBounds(1, 9)}, nil,
+		intlist.ErrOutOfBounds}, // Bounds
+	{"other=5", []intlist.Option{intlist.WithNonEmpty()}, nil,
+		intlist.ErrEmpty}, // Required
+	{"ids=1..3", nil, nil, strconv.ErrSyntax}, // Bad ellipsis
+}
+
+func TestFromQuery(t *testing.T) {
+	for _, test := range fromQueryTests {
+		q, _ := url.ParseQuery(test.query)
+		list, err := intlist.FromQuery(q, "ids", test.opts...)
+		if !errors.Is(err, test.err) {
+			t.Errorf("FromQuery(%q) error = (%v) -- wanted (%v)",
+				test.query, err, test.err)
+			continue
+		}
+		if err != nil {
+			if !strings.Contains(err.Error(), `"ids"`) {
+				t.Errorf("FromQuery(%q) error %q does not name parameter",
+					test.query, err)
+			}
+			continue
+		}
+		if out := list.Ints(); !cmp.Equal(out, test.out) {
+			t.Errorf("FromQuery(%q) = %v -- wanted %v", test.query, out,
+				test.out)
+		}
+	}
+}
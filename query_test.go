@@ -0,0 +1,358 @@
+// Copyright 2020 Brian E. Holland. All rights reserved.
+// The use of this source code is governed by an MIT license
+// that can be found in the LICENSE file.
+
+package intlist_test
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/brianholland99/intlist"
+	"github.com/google/go-cmp/cmp"
+)
+
+type describeTest struct {
+	in  string
+	out intlist.Summary
+	err error
+}
+
+var describeTests = []describeTest{
+	// Good cases
+	{"1...10,30...21,50", intlist.Summary{Items: 3, Values: 21, Min: 1,
+		Max: 50, Descending: 1, Density: 0.42}, nil}, // Mixed
+	{"7,7", intlist.Summary{Items: 2, Values: 2, Min: 7, Max: 7,
+		Density: 2}, nil}, // Repeated
+	{"1...20,!5,!6...7", intlist.Summary{Items: 3, Values: 17, Min: 1, Max: 20,
+		Density: 0.85}, nil}, // Exclusions
+	{"20...1,!5,!10...12", intlist.Summary{Items: 3, Values: 16, Min: 1, Max: 20,
+		Descending: 1, Density: 0.8}, nil}, // Split by exclusions
+	{"", intlist.Summary{}, nil}, // Empty list
+	// Error cases
+	{"1...", intlist.Summary{}, strconv.ErrSyntax}, // Missing end
+}
+
+func TestDescribe(t *testing.T) {
+	for _, test := range describeTests {
+		out, err := intlist.Describe(test.in)
+		if out != test.out || !errors.Is(err, test.err) {
+			t.Errorf("Describe(%q) = (%+v), (%v) -- wanted (%+v), (%v)",
+				test.in, out, err, test.out, test.err)
+		}
+	}
+}
+
+type countTest struct {
+	in  string
+	out uint64
+	err error
+}
+
+var countTests = []countTest{
+	// Good cases
+	{"1...1000000000,5,0...100:10", 1000000012, nil}, // Huge sequence
+	{"10...1,3,3", 12, nil},                          // Repeats counted
+	{"", 0, nil},                                     // Empty list
+	// Error cases
+	{"1...", 0, strconv.ErrSyntax}, // Missing end
+}
+
+func TestCount(t *testing.T) {
+	for _, test := range countTests {
+		out, err := intlist.Count(test.in)
+		if out != test.out || !errors.Is(err, test.err) {
+			t.Errorf("Count(%q) = (%v), (%v) -- wanted (%v), (%v)",
+				test.in, out, err, test.out, test.err)
+		}
+	}
+	if strconv.IntSize == 64 {
+		// Every int is more values than a uint64 holds.
+		all := "-9223372036854775808...9223372036854775807"
+		if _, err := intlist.Count(all); !errors.Is(err, strconv.ErrRange) {
+			t.Errorf("Count(%q) error = (%v) -- wanted (%v)", all, err, strconv.ErrRange)
+		}
+	}
+}
+
+type boundsTest struct {
+	in       string
+	min, max int
+	err      error
+}
+
+var boundsTests = []boundsTest{
+	// Good cases
+	{"20...30,500,10...1", 1, 500, nil}, // Mixed
+	{"0...10:3", 0, 9, nil},             // Step short of the end
+	{"-7", -7, -7, nil},                 // Single value
+	// Error cases
+	{"", 0, 0, intlist.ErrNotFound},
+	{"1...", 0, 0, strconv.ErrSyntax},
+}
+
+func TestBounds(t *testing.T) {
+	for _, test := range boundsTests {
+		min, max, err := intlist.Bounds(test.in)
+		if min != test.min || max != test.max || !errors.Is(err, test.err) {
+			t.Errorf("Bounds(%q) = (%d), (%d), (%v) -- wanted (%d), (%d), (%v)",
+				test.in, min, max, err, test.min, test.max, test.err)
+		}
+	}
+}
+
+type queryTest struct {
+	in  string
+	x   int
+	out int
+	err error
+}
+
+// checkQuery runs the tests of a query on a value.
+func checkQuery(t *testing.T, name string, fn func(string, int) (int, error),
+	tests []queryTest) {
+	t.Helper()
+	for _, test := range tests {
+		out, err := fn(test.in, test.x)
+		if out != test.out || !errors.Is(err, test.err) {
+			t.Errorf("%s(%q, %d) = (%v), (%v) -- wanted (%v), (%v)",
+				name, test.in, test.x, out, err, test.out, test.err)
+		}
+	}
+}
+
+var rankTests = []queryTest{
+	// Good cases
+	{"1...10,5...15,30", 12, 12, nil}, // Overlap counted once
+	{"30,15...5,1...10", 30, 16, nil}, // Order does not matter
+	{"1...10", 0, 0, nil},             // Below every value
+	{"-5...-1,3", 2, 5, nil},          // Between values
+	{"0...200000000:2", 100, 51, nil}, // Steps
+	{"200000000...0:2", 200000000, 100000001, nil},
+	{"0...20:5,0...20:2", 10, 7, nil},
+	{"", 7, 0, nil}, // Empty list
+	// Error cases
+	{"1..5", 3, 0, strconv.ErrSyntax},
+}
+
+func TestRank(t *testing.T) {
+	checkQuery(t, "Rank", intlist.Rank, rankTests)
+	if strconv.IntSize == 64 {
+		// Every int below 1 is one more than the largest int.
+		checkQuery(t, "Rank", intlist.Rank, []queryTest{
+			{"-9223372036854775808...9223372036854775807", 0, 0, strconv.ErrRange},
+		})
+	}
+}
+
+var nextTests = []queryTest{
+	// Good cases
+	{"1...10,20...30", 11, 20, nil}, // Between sequences
+	{"1...10,20...30", 5, 5, nil},   // Within a sequence
+	{"30...20,10...1", 0, 1, nil},   // Below every value
+	{"30...20,25,12", 21, 21, nil},  // Decreasing sequence
+	{"40,12,15", 13, 15, nil},       // Unordered singles
+	// Error cases
+	{"1...10,20...30", 31, 0, intlist.ErrNotFound},
+	{"", 0, 0, intlist.ErrNotFound},
+	{"1..5", 3, 0, strconv.ErrSyntax},
+}
+
+var prevTests = []queryTest{
+	// Good cases
+	{"1...10,20...30", 15, 10, nil}, // Between sequences
+	{"1...10,20...30", 25, 25, nil}, // Within a sequence
+	{"30...20,10...1", 99, 30, nil}, // Above every value
+	{"3,9,6", 8, 6, nil},            // Unordered singles
+	// Error cases
+	{"1...10,20...30", 0, 0, intlist.ErrNotFound},
+	{"", 0, 0, intlist.ErrNotFound},
+	{"1..5", 3, 0, strconv.ErrSyntax},
+}
+
+func TestNext(t *testing.T) {
+	checkQuery(t, "Next", intlist.Next, nextTests)
+}
+
+func TestPrev(t *testing.T) {
+	checkQuery(t, "Prev", intlist.Prev, prevTests)
+}
+
+type endsTest struct {
+	in  string
+	n   int
+	out []int
+	err error
+}
+
+var headTests = []endsTest{
+	// Good cases
+	{"1...1000000000,5", 3, []int{1, 2, 3}, nil},
+	{"3...1,7,9", 5, []int{3, 2, 1, 7, 9}, nil}, // Across items
+	{"1,2", 5, []int{1, 2}, nil},                // Fewer values
+	{"1,2", 0, []int{}, nil},
+	{"", 3, []int{}, nil},
+	// Error cases
+	{"1,2", -1, nil, intlist.ErrInvalidArg},
+	{"1..2", 1, nil, strconv.ErrSyntax},
+}
+
+var tailTests = []endsTest{
+	// Good cases
+	{"5,1...1000000000", 3, []int{999999998, 999999999, 1000000000}, nil},
+	{"3...1,7,9", 4, []int{2, 1, 7, 9}, nil}, // Across items
+	{"1,2", 5, []int{1, 2}, nil},             // Fewer values
+	{"1,2", 0, []int{}, nil},
+	{"", 3, []int{}, nil},
+	// Error cases
+	{"1,2", -1, nil, intlist.ErrInvalidArg},
+	{"1..2", 1, nil, strconv.ErrSyntax},
+}
+
+// checkEnds runs the tests of Head or Tail.
+func checkEnds(t *testing.T, name string, fn func(string, int) ([]int, error),
+	tests []endsTest) {
+	t.Helper()
+	for _, test := range tests {
+		out, err := fn(test.in, test.n)
+		if !cmp.Equal(out, test.out) || !errors.Is(err, test.err) {
+			t.Errorf("%s(%q, %d) = (%v), (%v) -- wanted (%v), (%v)",
+				name, test.in, test.n, out, err, test.out, test.err)
+		}
+	}
+}
+
+func TestHead(t *testing.T) {
+	checkEnds(t, "Head", intlist.Head, headTests)
+}
+
+func TestTail(t *testing.T) {
+	checkEnds(t, "Tail", intlist.Tail, tailTests)
+}
+
+var coversTests = []struct {
+	in     string
+	lo, hi int
+	out    bool
+	err    error
+}{
+	// Good cases
+	{"1...10,11...20,25", 5, 20, true, nil},                         // Adjacent sequences
+	{"20...5,1...6", 1, 20, true, nil},                              // Overlapping and unordered
+	{"1...10,12...20", 5, 20, false, nil},                           // Hole
+	{"1...10", 0, 5, false, nil},                                    // Starts too low
+	{"7", 7, 7, true, nil},                                          // Single value
+	{"0...2000000000:2,1...1999999999:2", 0, 2000000000, true, nil}, // Steps
+	{"0...2000000000:2", 4, 4, true, nil},
+	{"0...2000000000:2", 4, 5, false, nil},
+	{"0...10:2,3", 2, 4, true, nil},
+	{"", 7, 7, false, nil}, // Empty list
+	// Error cases
+	{"1...10", 5, 4, false, intlist.ErrInvalidArg},
+	{"1..10", 1, 2, false, strconv.ErrSyntax},
+}
+
+func TestCovers(t *testing.T) {
+	for _, test := range coversTests {
+		out, err := intlist.Covers(test.in, test.lo, test.hi)
+		if out != test.out || !errors.Is(err, test.err) {
+			t.Errorf("Covers(%q, %d, %d) = (%v), (%v) -- wanted (%v), (%v)",
+				test.in, test.lo, test.hi, out, err, test.out, test.err)
+		}
+	}
+}
+
+var itemOfTests = []queryTest{
+	// Good cases
+	{"1...10,20...30,25", 25, 1, nil}, // First holding item
+	{"1...10,30...20", 20, 1, nil},    // Decreasing sequence
+	{"4,5,6", 6, 2, nil},
+	{"1...10,20...30,!5,!25", 26, 1, nil}, // Exclusions splitting items
+	{"5,!5,7", 7, 2, nil},                 // Removed item
+	// Error cases
+	{"1...10,20...30", 15, 0, intlist.ErrNotFound},
+	{"1...10,!5", 5, 0, intlist.ErrNotFound},
+	{"", 0, 0, intlist.ErrNotFound},
+	{"1..5", 3, 0, strconv.ErrSyntax},
+}
+
+func TestItemOf(t *testing.T) {
+	checkQuery(t, "ItemOf", intlist.ItemOf, itemOfTests)
+}
+
+type containsTest struct {
+	in  string
+	v   int
+	out bool
+	err error
+}
+
+var containsTests = []containsTest{
+	// Good cases
+	{"1...10000000,-5", 4096, true, nil}, // Huge sequence
+	{"1...10000000,-5", -5, true, nil},   // Single value
+	{"30...20", 25, true, nil},           // Decreasing sequence
+	{"0...100:5", 12, false, nil},        // Between steps
+	{"0...100:5", 95, true, nil},         // On a step
+	{"1...10,20...30", 15, false, nil},   // Hole
+	{"", 0, false, nil},                  // Empty list
+	// Error cases
+	{"1..5", 3, false, strconv.ErrSyntax},
+}
+
+func TestContains(t *testing.T) {
+	for _, test := range containsTests {
+		out, err := intlist.Contains(test.in, test.v)
+		if out != test.out || !errors.Is(err, test.err) {
+			t.Errorf("Contains(%q, %d) = (%v), (%v) -- wanted (%v), (%v)",
+				test.in, test.v, out, err, test.out, test.err)
+		}
+		if test.err != nil {
+			continue
+		}
+		list, err := intlist.Compile(test.in, intlist.WithFeatures(intlist.FeatureStep))
+		if err != nil {
+			t.Fatalf("Compile(%q) error = %v", test.in, err)
+		}
+		if out := list.Contains(test.v); out != test.out {
+			t.Errorf("Compile(%q).Contains(%d) = %v -- wanted %v",
+				test.in, test.v, out, test.out)
+		}
+	}
+}
+
+type windowTest struct {
+	in     string
+	lo, hi int
+	out    string
+	err    error
+}
+
+var overlapRangeTests = []windowTest{
+	// Good cases
+	{"1...10,30...20,15", 8, 22, "8...10,22...20,15", nil}, // Order kept
+	{"1...10,5", 5, 5, "5,5", nil},                         // Repeats kept
+	{"1...10,30...20", 12, 18, "", nil},                    // Hole
+	{"10...1", 1, 1, "1", nil},                             // Single value
+	{"", 1, 9, "", nil},                                    // Empty list
+	// Error cases
+	{"1...10", 5, 4, "", intlist.ErrInvalidArg},
+	{"1..10", 1, 2, "", strconv.ErrSyntax},
+}
+
+func TestOverlapRange(t *testing.T) {
+	for _, test := range overlapRangeTests {
+		out, err := intlist.OverlapRange(test.in, test.lo, test.hi)
+		if out != test.out || !errors.Is(err, test.err) {
+			t.Errorf("OverlapRange(%q, %d, %d) = (%q), (%v) -- wanted (%q), (%v)",
+				test.in, test.lo, test.hi, out, err, test.out, test.err)
+		}
+		// Overlaps agrees with OverlapRange.
+		ok, err := intlist.Overlaps(test.in, test.lo, test.hi)
+		if ok != (test.out != "") || !errors.Is(err, test.err) {
+			t.Errorf("Overlaps(%q, %d, %d) = (%v), (%v) -- wanted (%v), (%v)",
+				test.in, test.lo, test.hi, ok, err, test.out != "", test.err)
+		}
+	}
+}
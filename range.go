@@ -0,0 +1,52 @@
+// Copyright 2020 Brian E. Holland. All rights reserved.
+// The use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package intlist
+
+// Range is an item of a specification as the values Start, Start+Step, and so
+// on through End. Step is negative for a decreasing sequence and 0 for a
+// single value, where Start equals End. End is always one of the values.
+// Reps is the number of extra copies of a single value (E.g., 2 for "7x3").
+type Range struct {
+	Start, End, Step int
+	Reps             int
+}
+
+// Len returns the number of values of r or 0 if that overflows a uint64.
+func (r Range) Len() uint64 {
+	return seq{next: r.Start, last: r.End, step: r.Step, reps: r.Reps}.len()
+}
+
+// Ranges returns the items of the passed specification as Ranges in the same
+// order, so schedulers and partitioners can work with the intervals instead
+// of the values.
+//
+//   Ranges("1...10,7x2,30...20:5") -> []Range{{1, 10, 1, 0}, {7, 7, 0, 1},
+//       {30, 20, -5, 0}}, nil
+//
+// Potential errors returned:
+//
+//   strconv.ErrSyntax - Error parsing integer or sequence notation
+//   strconv.ErrRange - Integer out of range
+func Ranges(spec string) ([]Range, error) {
+	seqs, err := parseSeqs("Ranges", spec)
+	if err != nil {
+		return nil, err
+	}
+	return seqRanges(seqs), nil
+}
+
+// Ranges returns the items of l as Ranges in the same order.
+func (l *List) Ranges() []Range {
+	return seqRanges(l.sequences())
+}
+
+// seqRanges returns the Ranges for seqs.
+func seqRanges(seqs []seq) []Range {
+	result := make([]Range, len(seqs))
+	for i, s := range seqs {
+		result[i] = Range{Start: s.next, End: s.last, Step: s.step, Reps: s.reps}
+	}
+	return result
+}
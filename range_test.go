@@ -0,0 +1,57 @@
+// Copyright 2020 Brian E. Holland. All rights reserved.
+// The use of this source code is governed by an MIT license
+// that can be found in the LICENSE file.
+
+package intlist_test
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/brianholland99/intlist"
+	"github.com/google/go-cmp/cmp"
+)
+
+type rangesTest struct {
+	in  string
+	out []intlist.Range
+	err error
+}
+
+var rangesTests = []rangesTest{
+	// Good cases
+	{"1...10,7,30...20:5", []intlist.Range{{1, 10, 1, 0}, {7, 7, 0, 0}, {30, 20, -5, 0}}, nil},
+	{"0...10:4", []intlist.Range{{0, 8, 4, 0}}, nil},            // End reached
+	{"5...5", []intlist.Range{{5, 5, 0, 0}}, nil},               // Single value
+	{"4,1x3", []intlist.Range{{4, 4, 0, 0}, {1, 1, 0, 2}}, nil}, // Repeats
+	{"", []intlist.Range{}, nil},                                // Empty list
+	// Error cases
+	{"1..3", nil, strconv.ErrSyntax},
+}
+
+func TestRanges(t *testing.T) {
+	for _, test := range rangesTests {
+		out, err := intlist.Ranges(test.in)
+		if !cmp.Equal(out, test.out) || !errors.Is(err, test.err) {
+			t.Errorf("Ranges(%q) = (%v), (%v) -- wanted (%v), (%v)",
+				test.in, out, err, test.out, test.err)
+		}
+		if test.err != nil {
+			continue
+		}
+		list, _ := intlist.Compile(test.in,
+			intlist.WithFeatures(intlist.FeatureStep|intlist.FeatureRepeat))
+		if out := list.Ranges(); !cmp.Equal(out, test.out) {
+			t.Errorf("Compile(%q).Ranges() = %v -- wanted %v", test.in, out, test.out)
+		}
+		// The lengths of the Ranges add up to the values.
+		var n uint64
+		for _, r := range out {
+			n += r.Len()
+		}
+		if count, _ := intlist.Count(test.in); n != count {
+			t.Errorf("Ranges(%q) lengths add up to %d -- wanted %d", test.in, n, count)
+		}
+	}
+}
@@ -0,0 +1,52 @@
+// Copyright 2020 Brian E. Holland. All rights reserved.
+// The use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package intlist
+
+import "strings"
+
+// romanDigits are the values of Roman numeral letters from largest to
+// smallest, including the subtractive pairs.
+var romanDigits = []struct {
+	letters string
+	value   int
+}{
+	{"M", 1000}, {"CM", 900}, {"D", 500}, {"CD", 400},
+	{"C", 100}, {"XC", 90}, {"L", 50}, {"XL", 40},
+	{"X", 10}, {"IX", 9}, {"V", 5}, {"IV", 4}, {"I", 1},
+}
+
+// parseRoman parses tok as a Roman numeral from 1 ("I") through 3999
+// ("MMMCMXCIX") written in the standard form, using either all upper-case or
+// all lower-case letters, as for the front matter of a book (E.g., "xiv"). It
+// reports false for anything else, such as "IIII" or "Xv".
+func parseRoman(tok string) (int, bool, error) {
+	upper := strings.ToUpper(tok)
+	if tok != upper && tok != strings.ToLower(tok) {
+		return 0, false, nil
+	}
+	rest, v := upper, 0
+	for _, d := range romanDigits {
+		// No letters repeat more than three times in the standard form.
+		for n := 0; strings.HasPrefix(rest, d.letters) && n < 3; n++ {
+			rest, v = rest[len(d.letters):], v+d.value
+		}
+	}
+	if tok == "" || rest != "" || formatRoman(v) != upper {
+		return 0, false, nil
+	}
+	return v, true, nil
+}
+
+// formatRoman returns v, which must be from 1 through 3999, as an upper-case
+// Roman numeral in the standard form.
+func formatRoman(v int) string {
+	var b strings.Builder
+	for _, d := range romanDigits {
+		for ; v >= d.value; v -= d.value {
+			b.WriteString(d.letters)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,64 @@
+// Copyright 2020 Brian E. Holland. All rights reserved.
+// The use of this source code is governed by an MIT license
+// that can be found in the LICENSE file.
+
+package intlist_test
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/brianholland99/intlist"
+	"github.com/google/go-cmp/cmp"
+)
+
+var romanTests = []parseTest{
+	// Good cases
+	{"I...V", []int{1, 2, 3, 4, 5}, nil},
+	{"xii...ix", []int{12, 11, 10, 9}, nil}, // Lower case
+	{"XL,XC,CD,CM,MCMXCIV,MMMCMXCIX", []int{40, 90, 400, 900, 1994, 3999}, nil},
+	{"iv...7", []int{4, 5, 6, 7}, nil}, // Mixed with integers
+	// Error cases
+	{"IIII", nil, strconv.ErrSyntax}, // Too many repeats
+	{"VX", nil, strconv.ErrSyntax},   // Not the standard form
+	{"MMMM", nil, strconv.ErrSyntax}, // Past 3999
+	{"iX", nil, strconv.ErrSyntax},   // Mixed case
+	{"I...", nil, strconv.ErrSyntax}, // Missing end
+	{"-V", nil, strconv.ErrSyntax},   // No sign
+	{"IVX", nil, strconv.ErrSyntax},  // Not a numeral
+	{"XIIz", nil, strconv.ErrSyntax}, // Trailing letter
+}
+
+func TestRoman(t *testing.T) {
+	for _, test := range romanTests {
+		out, err := intlist.ParseWithOptions(test.in, intlist.WithFeatures(intlist.FeatureRoman))
+		if !cmp.Equal(out, test.out) || !errors.Is(err, test.err) {
+			t.Errorf("ParseWithOptions(%q, WithFeatures(FeatureRoman)) = (%v), (%v) -- "+
+				"wanted (%v), (%v)", test.in, out, err, test.out, test.err)
+		}
+	}
+	// Every numeral gives its value.
+	for v := 1; v <= 3999; v++ {
+		roman := toRoman(v)
+		if out, err := intlist.ParseWithOptions(roman,
+			intlist.WithFeatures(intlist.FeatureRoman)); err != nil || out[0] != v {
+			t.Fatalf("ParseWithOptions(%q) = (%v), (%v) -- wanted [%d]", roman, out, err, v)
+		}
+	}
+}
+
+// toRoman returns v as a Roman numeral by the rules for each decimal digit.
+func toRoman(v int) string {
+	digits := [][]string{
+		{"", "I", "II", "III", "IV", "V", "VI", "VII", "VIII", "IX"},
+		{"", "X", "XX", "XXX", "XL", "L", "LX", "LXX", "LXXX", "XC"},
+		{"", "C", "CC", "CCC", "CD", "D", "DC", "DCC", "DCCC", "CM"},
+		{"", "M", "MM", "MMM"},
+	}
+	roman := ""
+	for place := 0; v > 0; place, v = place+1, v/10 {
+		roman = digits[place][v%10] + roman
+	}
+	return roman
+}
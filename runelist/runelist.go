@@ -0,0 +1,164 @@
+// Copyright 2020 Brian E. Holland. All rights reserved.
+// The use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+// Package runelist supports the intlist notation for series of characters,
+// such as the characters of a character class in a configuration file.
+//
+// Format:
+//   - Comma-separated expressions of characters or character sequences.
+//   - An empty string indicates an empty list.
+//   - Sequences are consecutive code points notated by two endpoints
+//     separated by an ellipsis and includes both endpoints.
+//   - Both increasing and decreasing sequences are supported.
+//   - Each endpoint is a single character or a code point written as "U+"
+//     followed by four to six hexadecimal digits (E.g., "U+002C" for a
+//     comma, which can't be written as itself).
+//
+// Examples:
+//
+//   spec = "a...e,_" --> ['a', 'b', 'c', 'd', 'e', '_']
+//   spec = "0...2,À...Â,U+002C" --> ['0', '1', '2', 'À', 'Á', 'Â', ',']
+//
+// Sequences are expanded by an intlist.Iterator, so an Iterator never holds
+// the whole expansion.
+package runelist
+
+import (
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/brianholland99/intlist"
+)
+
+// Surrogate code points, which are not valid runes
+const (
+	surrogateMin = 0xD800
+	surrogateMax = 0xDFFF
+)
+
+// Iterator is the state for generating runes from a runelist description.
+type Iterator struct {
+	it  *intlist.Iterator // Source of the code points
+	err error             // Error in creating, if any
+}
+
+// NewIterator validates the specification and sets the state for iteration.
+//
+//   NewIterator("a...c,U+0041") -> ['a' 'b' 'c' 'A']
+//
+// Potential errors set in state during creation of an Iterator:
+//
+//   strconv.ErrSyntax - Error parsing character or sequence notation
+//   strconv.ErrRange - Code point not a valid rune or a sequence holding
+//       surrogate code points
+func NewIterator(spec string) *Iterator {
+	ints, err := toIntSpec("NewIterator", spec)
+	if err != nil {
+		return &Iterator{err: err}
+	}
+	return &Iterator{it: intlist.NewIterator(ints)}
+}
+
+// Next returns the next rune if not done and an error to indicate if done.
+//
+// If intlist.ErrDone is returned the rune is not valid and there are no more
+// runes.
+//
+// It will panic for the following avoidable cases:
+//   - Next called on invalid iterator.
+//   - Next called after previous call to Next() returned ErrDone.
+func (i *Iterator) Next() (rune, error) {
+	if i.err != nil {
+		panic("Next() called on invalid iterator.")
+	}
+	v, err := i.it.Next()
+	return rune(v), err
+}
+
+// Err returns any error that occured when creating this Iterator.
+func (i *Iterator) Err() error {
+	return i.err
+}
+
+// Done reports whether a previous Next call returned intlist.ErrDone to
+// indicate that the end of the iteration occurred.
+func (i *Iterator) Done() bool {
+	return i.err == nil && i.it.Done()
+}
+
+// Parse returns the runes represented by the passed specification.
+//
+//   Parse("a...e,_") -> [a b c d e _], nil
+//
+// Potential errors returned are those of NewIterator.
+func Parse(spec string) ([]rune, error) {
+	it := NewIterator(spec)
+	if it.Err() != nil {
+		return nil, it.Err()
+	}
+	result := []rune{}
+	for {
+		r, err := it.Next()
+		if err == intlist.ErrDone {
+			return result, nil
+		}
+		result = append(result, r)
+	}
+}
+
+// toIntSpec returns the intlist specification of the code points of spec.
+// The "fn" parameter is the name of the calling function used in any error
+// returned.
+func toIntSpec(fn, spec string) (string, error) {
+	if spec == "" {
+		return "", nil
+	}
+	items := strings.Split(spec, ",")
+	for i, item := range items {
+		ends := strings.Split(item, "...")
+		if len(ends) > 2 {
+			return "", &strconv.NumError{Func: fn, Num: item, Err: strconv.ErrSyntax}
+		}
+		var points [2]int // Code points of the endpoints
+		for j, end := range ends {
+			r, err := codePoint(end)
+			if err != nil {
+				return "", &strconv.NumError{Func: fn, Num: item, Err: err}
+			}
+			points[j] = int(r)
+			ends[j] = strconv.Itoa(int(r))
+		}
+		if len(ends) == 2 {
+			lo, hi := points[0], points[1]
+			if lo > hi {
+				lo, hi = hi, lo
+			}
+			if lo <= surrogateMax && hi >= surrogateMin {
+				return "", &strconv.NumError{Func: fn, Num: item, Err: strconv.ErrRange}
+			}
+		}
+		items[i] = strings.Join(ends, "...")
+	}
+	return strings.Join(items, ","), nil
+}
+
+// codePoint returns the rune written as end, which is a single character or
+// "U+" followed by four to six hexadecimal digits.
+func codePoint(end string) (rune, error) {
+	if r, size := utf8.DecodeRuneInString(end); size == len(end) &&
+		(r != utf8.RuneError || size == 3) {
+		return r, nil // U+FFFD itself takes three bytes.
+	}
+	hex := strings.TrimPrefix(end, "U+")
+	if hex == end || len(hex) < 4 || len(hex) > 6 || strings.Trim(hex,
+		"0123456789ABCDEFabcdef") != "" {
+		return 0, strconv.ErrSyntax
+	}
+	v, _ := strconv.ParseUint(hex, 16, 32) // Checked to be 6 digits or fewer
+	if !utf8.ValidRune(rune(v)) {
+		return 0, strconv.ErrRange
+	}
+	return rune(v), nil
+}
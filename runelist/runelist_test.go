@@ -0,0 +1,72 @@
+// Copyright 2020 Brian E. Holland. All rights reserved.
+// The use of this source code is governed by an MIT license
+// that can be found in the LICENSE file.
+
+package runelist_test
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/brianholland99/intlist"
+	"github.com/brianholland99/intlist/runelist"
+	"github.com/google/go-cmp/cmp"
+)
+
+var parseTests = []struct {
+	in  string
+	out []rune
+	err error
+}{
+	// Good cases
+	{"a...e,_", []rune("abcde_"), nil},
+	{"0...2,À...Â,U+002C", []rune("012ÀÁÂ,"), nil},
+	{"c...a", []rune("cba"), nil},              // Decreasing
+	{"U+0041...U+0043,u", []rune("ABCu"), nil}, // Code points
+	{" ,.", []rune(" ."), nil},                 // Punctuation
+	{"�,U+10FFFF", []rune("�\U0010FFFF"), nil},
+	{"", []rune{}, nil}, // Empty list
+	// Error cases
+	{"ab", nil, strconv.ErrSyntax},        // Two characters
+	{"a..c", nil, strconv.ErrSyntax},      // Short ellipsis
+	{"a...b...c", nil, strconv.ErrSyntax}, // Multiple ellipses
+	{"a,,b", nil, strconv.ErrSyntax},      // Empty item
+	{"a...", nil, strconv.ErrSyntax},      // Missing end
+	{"U+41", nil, strconv.ErrSyntax},      // Too few digits
+	{"U+00G1", nil, strconv.ErrSyntax},    // Not hexadecimal
+	{"\xff", nil, strconv.ErrSyntax},      // Invalid UTF-8
+	{"U+110000", nil, strconv.ErrRange},   // Past the last code point
+	{"U+D800", nil, strconv.ErrRange},     // Surrogate
+	{"U+D000...U+E000", nil, strconv.ErrRange},
+}
+
+func TestParse(t *testing.T) {
+	for _, test := range parseTests {
+		out, err := runelist.Parse(test.in)
+		if !cmp.Equal(out, test.out) || !errors.Is(err, test.err) {
+			t.Errorf("Parse(%q) = (%q), (%v) -- wanted (%q), (%v)",
+				test.in, out, err, test.out, test.err)
+		}
+	}
+}
+
+func TestIterator(t *testing.T) {
+	it := runelist.NewIterator("x...z")
+	var out []rune
+	for !it.Done() {
+		r, err := it.Next()
+		if err == intlist.ErrDone {
+			break
+		}
+		out = append(out, r)
+	}
+	if !cmp.Equal(out, []rune("xyz")) || !it.Done() || it.Err() != nil {
+		t.Errorf("Iterator = %q, Done() = %v, Err() = %v -- wanted %q, true, nil",
+			out, it.Done(), it.Err(), "xyz")
+	}
+	if it := runelist.NewIterator("x..z"); !errors.Is(it.Err(), strconv.ErrSyntax) || it.Done() {
+		t.Errorf("NewIterator(%q) Err() = %v, Done() = %v -- wanted %v, false",
+			"x..z", it.Err(), it.Done(), strconv.ErrSyntax)
+	}
+}
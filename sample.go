@@ -0,0 +1,214 @@
+// Copyright 2020 Brian E. Holland. All rights reserved.
+// The use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package intlist
+
+import (
+	"fmt"
+	"math"
+	"math/bits"
+	"math/rand"
+)
+
+// Sample returns k values selected uniformly at random from the values
+// represented by the passed specification without expanding it.
+//
+// The values are taken from k distinct positions in the expansion, so a value
+// listed more than once in the specification may be returned more than once.
+// The result is in random order. The "r" parameter must not be nil.
+//
+//   Sample("1...1000000000", 3, r) -> [48213077 917 603311204], nil
+//
+// Potential errors returned:
+//
+//   strconv.ErrSyntax - Error parsing integer or sequence notation
+//   strconv.ErrRange - Integer out of range or too many values to count
+//   ErrInvalidArg - k is negative or larger than the number of values
+func Sample(spec string, k int, r *rand.Rand) ([]int, error) {
+	seqs, err := parseSeqs("Sample", spec)
+	if err != nil {
+		return nil, err
+	}
+	cum, err := cumLens("Sample", spec, seqs)
+	if err != nil {
+		return nil, err
+	}
+	var total uint64
+	if len(cum) > 0 {
+		total = cum[len(cum)-1]
+	}
+	if k < 0 || uint64(k) > total {
+		return nil, fmt.Errorf("intlist.Sample: k = %d with %d values: %w",
+			k, total, ErrInvalidArg)
+	}
+	// Floyd's algorithm picks k distinct positions with O(k) memory.
+	chosen := make(map[uint64]bool, k)
+	result := make([]int, 0, k)
+	for j := total - uint64(k); j < total; j++ {
+		pos := randUint64n(r, j+1)
+		if chosen[pos] {
+			pos = j
+		}
+		chosen[pos] = true
+		result = append(result, valueAt(seqs, cum, pos))
+	}
+	// Floyd's algorithm favors later positions at the end, so shuffle.
+	r.Shuffle(len(result), func(i, j int) {
+		result[i], result[j] = result[j], result[i]
+	})
+	return result, nil
+}
+
+// Spread returns k values of the passed specification taken at evenly spaced
+// positions of the expansion, always including the first and last values, so
+// a representative test subset is the same on every run. A k of 1 gives the
+// first value. The values are in the order of the expansion.
+//
+//   Spread("1...101", 5) -> [1 26 51 76 101], nil
+//
+// Potential errors returned:
+//
+//   strconv.ErrSyntax - Error parsing integer or sequence notation
+//   strconv.ErrRange - Integer out of range or too many values to count
+//   ErrInvalidArg - k is negative or larger than the number of values
+func Spread(spec string, k int) ([]int, error) {
+	const fn = "Spread"
+	seqs, err := parseSeqs(fn, spec)
+	if err != nil {
+		return nil, err
+	}
+	cum, err := cumLens(fn, spec, seqs)
+	if err != nil {
+		return nil, err
+	}
+	var total uint64
+	if len(cum) > 0 {
+		total = cum[len(cum)-1]
+	}
+	if k < 0 || uint64(k) > total {
+		return nil, fmt.Errorf("intlist.%s: k = %d with %d values: %w",
+			fn, k, total, ErrInvalidArg)
+	}
+	result := make([]int, 0, k)
+	for i := 0; i < k; i++ {
+		var pos uint64 // Position i*(total-1)/(k-1) rounded to the nearest
+		if k > 1 {
+			hi, lo := bits.Mul64(total-1, uint64(i))
+			var rem uint64
+			pos, rem = bits.Div64(hi, lo, uint64(k-1))
+			if rem >= uint64(k)-rem-1 {
+				pos++ // Remainder at least half of k-1
+			}
+		}
+		result = append(result, valueAt(seqs, cum, pos))
+	}
+	return result, nil
+}
+
+// ReservoirSample returns k values selected uniformly at random from the
+// values remaining in it, reading it to the end without holding more than k
+// values. Unlike Sample, it suits sources whose length is not known in
+// advance, such as a filtered or generated iteration. If there are fewer than
+// k values, all of them are returned. The result is in no particular order.
+// The "it" parameter must be a valid Iterator and "r" must not be nil. A k
+// that is not above 0 gives an empty result without reading it.
+//
+//   ReservoirSample(NewIterator("1...1000000"), 3, r) -> [482130 917 603311]
+func ReservoirSample(it *Iterator, k int, r *rand.Rand) []int {
+	result := []int{}
+	if k <= 0 {
+		return result
+	}
+	// Algorithm R keeps value n (origin 0) with probability k/(n+1).
+	for n := uint64(0); ; n++ {
+		val, err := it.Next()
+		if err != nil {
+			return result // ErrDone or an error of an Iterator set not to panic
+		}
+		if n < uint64(k) {
+			result = append(result, val)
+		} else if j := randUint64n(r, n+1); j < uint64(k) {
+			result[j] = val
+		}
+	}
+}
+
+// randUint64n returns a uniform random number in [0, n). The "n" parameter
+// must be greater than 0.
+func randUint64n(r *rand.Rand, n uint64) uint64 {
+	if n <= math.MaxInt64 {
+		return uint64(r.Int63n(int64(n)))
+	}
+	// Reject values from the incomplete final interval to avoid bias.
+	limit := math.MaxUint64 - math.MaxUint64%n
+	for {
+		if v := r.Uint64(); v < limit {
+			return v % n
+		}
+	}
+}
+
+// Stride validates the specification and sets the state for iterating through
+// every n-th value of its expansion, starting with the first value.
+//
+// The sequences are stepped through directly, so skipped values are never
+// generated. This is useful for downsampling long sequences.
+//
+//   Stride("1...10,20...25", 3) -> [1 4 7 10 22 25]
+//
+// Potential errors set in state during creation of an Iterator:
+//
+//   strconv.ErrSyntax - Error parsing integer or sequence notation
+//   strconv.ErrRange - Integer out of range
+//   ErrInvalidArg - n is less than 1
+func Stride(spec string, n int) *Iterator {
+	if n < 1 {
+		return &Iterator{
+			err: fmt.Errorf("intlist.Stride: n = %d: %w", n, ErrInvalidArg),
+		}
+	}
+	seqs, err := parseSeqs("Stride", spec)
+	if err != nil {
+		return &Iterator{err: err}
+	}
+	return &Iterator{seqs: strideSeqs(seqs, uint64(n))}
+}
+
+// strideSeqs returns the sequences producing every n-th value of seqs.
+func strideSeqs(seqs []seq, n uint64) []seq {
+	result := []seq{}
+	var skip uint64 // Values to skip at the start of the next sequence
+	for _, s := range seqs {
+		size := s.len() // Arithmetic below is also correct when 0 means 2^64
+		if size != 0 && skip >= size {
+			skip -= size // Whole sequence skipped
+			continue
+		}
+		more := (size - skip - 1) / n // Values taken after the first one
+		first, last := s.at(skip), s.at(skip+more*n)
+		mag := uint64(s.step) // Magnitude of the step
+		if s.step < 0 {
+			mag = -mag
+		}
+		hi, width := bits.Mul64(mag, n)
+		switch {
+		case s.step == 0: // Copies of a single value
+			result = append(result, seq{next: first, last: first, reps: int(more)})
+		case more == 0:
+			result = append(result, seq{next: first, last: first})
+		case hi == 0 && width <= maxInt:
+			step := int(width)
+			if s.step < 0 {
+				step = -step
+			}
+			result = append(result, seq{next: first, last: last, step: step})
+		default:
+			// The step overflows an int, so at most 2 values are taken.
+			result = append(result,
+				seq{next: first, last: first}, seq{next: last, last: last})
+		}
+		skip = skip + more*n + n - size
+	}
+	return result
+}
@@ -0,0 +1,196 @@
+// Copyright 2020 Brian E. Holland. All rights reserved.
+// The use of this source code is governed by an MIT license
+// that can be found in the LICENSE file.
+
+package intlist_test
+
+import (
+	"errors"
+	"math/rand"
+	"sort"
+	"strconv"
+	"testing"
+
+	"github.com/brianholland99/intlist"
+	"github.com/google/go-cmp/cmp"
+)
+
+type sampleTest struct {
+	in  string
+	k   int
+	err error
+}
+
+var sampleTests = []sampleTest{
+	// Good cases
+	{"1...1000000000", 5, nil},           // Huge sequence
+	{"1,5...3,9", 5, nil},                // Whole list
+	{"", 0, nil},                         // Empty list
+	{"-2000000000...2000000000", 3, nil}, // Large span
+	// Error cases
+	{"1...5", 6, intlist.ErrInvalidArg},  // Too many requested
+	{"1...5", -1, intlist.ErrInvalidArg}, // Negative count
+	{"1.5", 1, strconv.ErrSyntax},        // Bad spec
+}
+
+func TestSample(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for _, test := range sampleTests {
+		out, err := intlist.Sample(test.in, test.k, r)
+		if !errors.Is(err, test.err) {
+			t.Errorf("Sample(%q, %d) error = (%v) -- wanted (%v)",
+				test.in, test.k, err, test.err)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+		if len(out) != test.k {
+			t.Errorf("Sample(%q, %d) = %v -- wanted %d values",
+				test.in, test.k, out, test.k)
+		}
+	}
+}
+
+// Sampling every position must return a permutation of the expansion.
+func TestSampleWholeList(t *testing.T) {
+	spec := "1...3,7,5...3,9"
+	want, _ := intlist.Parse(spec)
+	out, err := intlist.Sample(spec, len(want), rand.New(rand.NewSource(2)))
+	if err != nil {
+		t.Fatalf("Sample(%q) error = %v", spec, err)
+	}
+	sort.Ints(want)
+	sort.Ints(out)
+	if !cmp.Equal(out, want) {
+		t.Errorf("Sample(%q) = %v -- wanted permutation of %v", spec, out, want)
+	}
+}
+
+var reservoirTests = []sampleTest{
+	{"1...100000", 5, nil}, // Long iteration
+	{"1,5...3,9", 5, nil},  // Whole list
+	{"1...3", 5, nil},      // Fewer values
+	{"", 2, nil},           // Empty list
+	{"1...5", 0, nil},      // Nothing requested
+	{"1...5", -1, nil},     // Negative count
+}
+
+func TestReservoirSample(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for _, test := range reservoirTests {
+		all, _ := intlist.Parse(test.in)
+		out := intlist.ReservoirSample(intlist.NewIterator(test.in), test.k, r)
+		want := test.k // Number of values expected
+		if want > len(all) {
+			want = len(all)
+		} else if want < 0 {
+			want = 0
+		}
+		if len(out) != want {
+			t.Errorf("ReservoirSample(%q, %d) = %v -- wanted %d values", test.in,
+				test.k, out, want)
+		}
+		// Sampling every value must return a permutation of the expansion.
+		if want == len(all) {
+			sort.Ints(all)
+			sort.Ints(out)
+			if !cmp.Equal(out, all) {
+				t.Errorf("ReservoirSample(%q, %d) = %v -- wanted permutation of %v",
+					test.in, test.k, out, all)
+			}
+		}
+	}
+}
+
+// Each value must be about equally likely to be selected.
+func TestReservoirSampleUniform(t *testing.T) {
+	const trials, n, k = 20000, 10, 3
+	r := rand.New(rand.NewSource(3))
+	var counts [n + 1]int
+	for i := 0; i < trials; i++ {
+		for _, v := range intlist.ReservoirSample(intlist.NewIterator("1...10"), k, r) {
+			counts[v]++
+		}
+	}
+	want := trials * k / n
+	for v := 1; v <= n; v++ {
+		if counts[v] < want*9/10 || counts[v] > want*11/10 {
+			t.Errorf("ReservoirSample selected %d %d times -- wanted about %d", v, counts[v], want)
+		}
+	}
+}
+
+var spreadTests = []strideTest{
+	// Good cases
+	{"1...101", 5, []int{1, 26, 51, 76, 101}, nil},
+	{"1...100", 5, []int{1, 26, 51, 75, 100}, nil}, // Rounded positions
+	{"10...1,20", 3, []int{10, 5, 20}, nil},        // Across items
+	{"1...3", 3, []int{1, 2, 3}, nil},              // Every value
+	{"1...9", 1, []int{1}, nil},                    // First value only
+	{"1...9", 0, []int{}, nil},
+	{"-2000000000...2000000000", 3, []int{-2000000000, 0, 2000000000}, nil},
+	// Error cases
+	{"1...3", 4, nil, intlist.ErrInvalidArg},
+	{"1...3", -1, nil, intlist.ErrInvalidArg},
+	{"1..3", 1, nil, strconv.ErrSyntax},
+}
+
+func TestSpread(t *testing.T) {
+	for _, test := range spreadTests {
+		out, err := intlist.Spread(test.in, test.n)
+		if !cmp.Equal(out, test.out) || !errors.Is(err, test.err) {
+			t.Errorf("Spread(%q, %d) = (%v), (%v) -- wanted (%v), (%v)",
+				test.in, test.n, out, err, test.out, test.err)
+		}
+	}
+}
+
+type strideTest struct {
+	in  string
+	n   int
+	out []int
+	err error
+}
+
+var strideTests = []strideTest{
+	// Good cases
+	{"1...10,20...25", 3, []int{1, 4, 7, 10, 22, 25}, nil},      // Across seqs
+	{"10...1", 4, []int{10, 6, 2}, nil},                         // Decreasing
+	{"1,2,3,4,5", 2, []int{1, 3, 5}, nil},                       // Single ints
+	{"1...3,4,5...9", 1, []int{1, 2, 3, 4, 5, 6, 7, 8, 9}, nil}, // Every one
+	{"1...3,7...8", 10, []int{1}, nil},                          // Huge stride
+	{"7x5", 2, []int{7, 7, 7}, nil},                             // Repeats
+	{"7x5", 1, []int{7, 7, 7, 7, 7}, nil},                       // Every repeat
+	{"1,7x4,2,3", 2, []int{1, 7, 7, 3}, nil},                    // Across repeats
+	{"", 2, []int{}, nil},                                       // Empty list
+	// Error cases
+	{"1...5", 0, nil, intlist.ErrInvalidArg}, // Zero stride
+	{"1.5", 2, nil, strconv.ErrSyntax},       // Bad spec
+}
+
+func TestStride(t *testing.T) {
+	for _, test := range strideTests {
+		it := intlist.Stride(test.in, test.n)
+		if !errors.Is(it.Err(), test.err) {
+			t.Errorf("Stride(%q, %d) error = (%v) -- wanted (%v)",
+				test.in, test.n, it.Err(), test.err)
+			continue
+		}
+		if test.err != nil {
+			continue
+		}
+		out := []int{}
+		for {
+			val, err := it.Next()
+			if err == intlist.ErrDone {
+				break
+			}
+			out = append(out, val)
+		}
+		if !cmp.Equal(out, test.out) {
+			t.Errorf("Stride(%q, %d) = %v -- wanted %v",
+				test.in, test.n, out, test.out)
+		}
+	}
+}
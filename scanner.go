@@ -0,0 +1,84 @@
+// Copyright 2020 Brian E. Holland. All rights reserved.
+// The use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package intlist
+
+import (
+	"bufio"
+	"errors"
+	"io"
+)
+
+// Scanner reads specifications one per line from an io.Reader, such as a data
+// file of specifications. Like bufio.Scanner, successive calls to Scan step
+// through the lines. The List method returns the parsed result of each line.
+//
+//   s := intlist.NewScanner(file)
+//   for s.Scan() {
+//       list, err := s.List()
+//       if err != nil {
+//           // Handle error. It holds the line number as a *ParseError.
+//       }
+//       fmt.Println(list.Ints()) // Or whatever processing is to be done.
+//   }
+//   if err := s.Err(); err != nil {
+//       // Handle read error.
+//   }
+type Scanner struct {
+	sc   *bufio.Scanner // Source of lines
+	c    *config        // Configuration for parsing each line
+	line int            // Number (origin 1) of the current line
+	list *List          // Result of the current line
+	err  error          // Error parsing the current line
+}
+
+// NewScanner returns a new Scanner reading from r. The "opts" parameter is
+// applied to the parsing of each line.
+func NewScanner(r io.Reader, opts ...Option) *Scanner {
+	c := newConfig(opts)
+	c.positions = true
+	return &Scanner{sc: bufio.NewScanner(r), c: c}
+}
+
+// Scan advances to the next line, which is then available through List. It
+// returns false when there are no more lines or reading failed, in which case
+// Err returns the read error, if any. A line that fails to parse does not stop
+// the scan.
+func (s *Scanner) Scan() bool {
+	s.list, s.err = nil, nil
+	if !s.sc.Scan() {
+		return false
+	}
+	s.line++
+	seqs, err := parseSpec("Scanner", s.sc.Text(), s.c)
+	if err != nil {
+		var pe *ParseError
+		if !errors.As(err, &pe) {
+			// Errors about the whole line only get a position here.
+			pe = &ParseError{Err: err, Column: 1}
+			err = pe
+		}
+		pe.Line = s.line // Each line is parsed as line 1 of a spec.
+		s.err = err
+		return true
+	}
+	s.list = &List{seqs: seqs}
+	return true
+}
+
+// List returns the parsed List of the current line or a *ParseError holding
+// the line number and column of the item in error.
+func (s *Scanner) List() (*List, error) {
+	return s.list, s.err
+}
+
+// Line returns the number (origin 1) of the current line.
+func (s *Scanner) Line() int {
+	return s.line
+}
+
+// Err returns the first error reading from the io.Reader, if any.
+func (s *Scanner) Err() error {
+	return s.sc.Err()
+}
@@ -0,0 +1,62 @@
+// Copyright 2020 Brian E. Holland. All rights reserved.
+// The use of this source code is governed by an MIT license
+// that can be found in the LICENSE file.
+
+package intlist_test
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/brianholland99/intlist"
+	"github.com/google/go-cmp/cmp"
+)
+
+type scanResult struct {
+	out    []int
+	err    error
+	column int // Column of the error if err is not nil
+}
+
+func TestScanner(t *testing.T) {
+	input := "1...3\n\n7,x\n5...4\n2,1..3"
+	want := []scanResult{
+		{[]int{1, 2, 3}, nil, 0},
+		{[]int{}, intlist.ErrEmpty, 1},
+		{nil, strconv.ErrSyntax, 3},
+		{[]int{5, 4}, nil, 0},
+		{nil, strconv.ErrSyntax, 3},
+	}
+	s := intlist.NewScanner(strings.NewReader(input), intlist.WithNonEmpty())
+	line := 0
+	for s.Scan() {
+		if line >= len(want) {
+			t.Fatalf("Scan() returned too many lines")
+		}
+		w := want[line]
+		line++
+		list, err := s.List()
+		if !errors.Is(err, w.err) {
+			t.Errorf("line %d error = %v -- wanted %v", line, err, w.err)
+			continue
+		}
+		if err != nil {
+			var parseErr *intlist.ParseError
+			if !errors.As(err, &parseErr) || parseErr.Line != line ||
+				parseErr.Column != w.column || s.Line() != line {
+				t.Errorf("line %d error = %v -- wanted line %d, column %d",
+					line, err, line, w.column)
+			}
+			continue
+		}
+		if out := list.Ints(); !cmp.Equal(out, w.out) {
+			t.Errorf("line %d = %v -- wanted %v", line, out, w.out)
+		}
+	}
+	if line != len(want) || s.Err() != nil {
+		t.Errorf("Scan() stopped after %d lines, Err() = %v -- wanted %d, nil",
+			line, s.Err(), len(want))
+	}
+}
@@ -0,0 +1,59 @@
+// Copyright 2020 Brian E. Holland. All rights reserved.
+// The use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package intlist
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// scientificPattern matches a number in exponent form, capturing the sign,
+// the digits before and after any decimal point and the exponent.
+var scientificPattern = regexp.MustCompile(`^([+-]?)(\d+)(?:\.(\d+))?[eE]([+-]?\d+)$`)
+
+// maxExponent is larger than the exponent of any int, so exponents are clamped
+// to it without changing the result.
+const maxExponent = 1000
+
+// parseScientific parses tok as an integer in exponent form (E.g., "1e3" or
+// "2.5E6"). It reports false if tok is not in that form. The result must be an
+// exact integer, so "1.5e0" and "150e-3" are syntax errors while "1500e-2" is
+// 15.
+func parseScientific(tok string) (int, bool, error) {
+	m := scientificPattern.FindStringSubmatch(tok)
+	if m == nil {
+		return 0, false, nil
+	}
+	sign, digits := m[1], m[2]+m[3]
+	exp, _ := strconv.Atoi(m[4]) // Out of range gives the nearest int.
+	if exp > maxExponent {
+		exp = maxExponent
+	} else if exp < -maxExponent {
+		exp = -maxExponent
+	}
+	exp -= len(m[3]) // Power of ten multiplying digits
+	digits = strings.TrimLeft(digits, "0")
+	switch {
+	case digits == "":
+		return 0, true, nil
+	case exp < 0:
+		// Only trailing zeros may be divided away.
+		kept := len(digits) + exp
+		if kept <= 0 || strings.TrimRight(digits[kept:], "0") != "" {
+			return 0, true, strconv.ErrSyntax
+		}
+		digits = digits[:kept]
+	case len(digits)+exp > len(strconv.Itoa(minInt)):
+		digits = strconv.Itoa(maxInt) + "0" // Certainly out of range
+	default:
+		digits += strings.Repeat("0", exp)
+	}
+	v, err := strconv.Atoi(sign + digits)
+	if err != nil {
+		return v, true, strconv.ErrRange // Only possible error with these digits
+	}
+	return v, true, nil
+}
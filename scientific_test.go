@@ -0,0 +1,60 @@
+// Copyright 2020 Brian E. Holland. All rights reserved.
+// The use of this source code is governed by an MIT license
+// that can be found in the LICENSE file.
+
+package intlist_test
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/brianholland99/intlist"
+	"github.com/google/go-cmp/cmp"
+)
+
+var scientificTests = []parseTest{
+	// Good cases
+	{"1e3...1.002E3", []int{1000, 1001, 1002}, nil},
+	{"2.5e1,-3e0,+4e+1", []int{25, -3, 40}, nil}, // Signs
+	{"1500e-2,0e99999", []int{15, 0}, nil},       // Exact after dividing
+	{"0.0e-5,00012e1", []int{0, 120}, nil},       // Leading zeros
+	// Error cases
+	{"1.5e0", nil, strconv.ErrSyntax},    // Not an integer
+	{"150e-3", nil, strconv.ErrSyntax},   // Not an integer
+	{"1e-99999", nil, strconv.ErrSyntax}, // Not an integer
+	{"1e", nil, strconv.ErrSyntax},       // Missing exponent
+	{".5e1", nil, strconv.ErrSyntax},     // Missing leading digit
+	{"1e19", nil, strconv.ErrRange},
+	{"1e99999999999999999999", nil, strconv.ErrRange},
+}
+
+func TestScientific(t *testing.T) {
+	for _, test := range scientificTests {
+		out, err := intlist.ParseWithOptions(test.in,
+			intlist.WithFeatures(intlist.FeatureScientific))
+		if !cmp.Equal(out, test.out) || !errors.Is(err, test.err) {
+			t.Errorf("ParseWithOptions(%q, WithFeatures(FeatureScientific)) = (%v), (%v) -- "+
+				"wanted (%v), (%v)", test.in, out, err, test.out, test.err)
+		}
+	}
+	// Strict parsing rejects the extension, while a query accepts it.
+	if _, err := intlist.Parse("1e3"); !errors.Is(err, intlist.ErrFeature) {
+		t.Errorf("Parse(%q) error = %v -- wanted %v", "1e3", err, intlist.ErrFeature)
+	}
+	if n, err := intlist.Rank("1e3...2e3", 1e6); n != 1001 || err != nil {
+		t.Errorf("Rank(%q) = (%v), (%v) -- wanted (1001), (nil)", "1e3...2e3", n, err)
+	}
+}
+
+func TestScientificSaturation(t *testing.T) {
+	var warned []error
+	out, err := intlist.ParseWithOptions("-1e30,5e0",
+		intlist.WithSaturation(func(err error) { warned = append(warned, err) }),
+		intlist.WithFeatures(intlist.FeatureScientific))
+	want := []int{-1 << (strconv.IntSize - 1), 5}
+	if !cmp.Equal(out, want) || err != nil || len(warned) != 1 {
+		t.Errorf("ParseWithOptions(%q, WithSaturation...) = (%v), (%v), %d warnings -- "+
+			"wanted (%v), (nil), 1 warning", "-1e30,5e0", out, err, len(warned), want)
+	}
+}
@@ -0,0 +1,751 @@
+// Copyright 2020 Brian E. Holland. All rights reserved.
+// The use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package intlist
+
+import (
+	"math"
+	"math/bits"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// span is an increasing sequence of integers from lo through hi. Lists of
+// spans are used when a specification is treated as a set of values.
+type span struct {
+	lo, hi int // Smallest and largest values
+	step   int // Distance between values if above one or else consecutive
+}
+
+// maxPeriod is the largest common multiple of the steps of spans that share a
+// range which normalize checks for a single sequence covering their values.
+const maxPeriod = 1024
+
+// stride returns the distance between the values of sp.
+func (sp span) stride() int {
+	if sp.step > 1 {
+		return sp.step
+	}
+	return 1
+}
+
+// seq returns the increasing sequence of the values of sp.
+func (sp span) seq() seq {
+	if sp.lo == sp.hi {
+		return seq{next: sp.lo, last: sp.hi}
+	}
+	return seq{next: sp.lo, last: sp.hi, step: sp.stride()}
+}
+
+// contains reports whether v is one of the values of sp.
+func (sp span) contains(v int) bool {
+	return sp.seq().contains(v)
+}
+
+// len returns the number of values of sp, or 0 for all 2^64 integers.
+func (sp span) len() uint64 {
+	return sp.seq().len()
+}
+
+// clip returns the values of sp from lo through hi, reporting false if there
+// are none.
+func (sp span) clip(lo, hi int) (span, bool) {
+	s, ok := sp.seq().within(lo, hi)
+	if !ok {
+		return sp, false
+	}
+	return s.toSpan(), true
+}
+
+// toSpan returns the span of the values of s, whose stride must fit in an int.
+func (s seq) toSpan() span {
+	lo, hi := s.bounds()
+	sp := span{lo: lo, hi: hi}
+	if stride := s.stride(); lo != hi && stride > 1 {
+		sp.step = int(stride)
+	}
+	return sp
+}
+
+// spans returns the spans covering the values of s in increasing order. That
+// is a single span unless the stride is too large for an int, leaving two
+// values.
+func (s seq) spans() []span {
+	if s.stride() > math.MaxInt {
+		lo, hi := s.bounds()
+		return []span{{lo: lo, hi: lo}, {lo: hi, hi: hi}}
+	}
+	return []span{s.toSpan()}
+}
+
+// seqSpans returns the spans covering the values of seqs in the same order.
+func seqSpans(seqs []seq) []span {
+	result := make([]span, 0, len(seqs))
+	for _, s := range seqs {
+		result = append(result, s.spans()...)
+	}
+	return result
+}
+
+// gcd returns the greatest common divisor of a and b.
+func gcd(a, b uint64) uint64 {
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return a
+}
+
+// lcm returns the least common multiple of a and b, reporting false if it
+// overflows.
+func lcm(a, b uint64) (uint64, bool) {
+	hi, lo := bits.Mul64(a/gcd(a, b), b)
+	return lo, hi == 0
+}
+
+// intersect returns the spans of the values in both a and b in increasing
+// order.
+func (sp span) intersect(b span) []span {
+	first, ok := firstCommon(sp.seq(), b.seq())
+	if !ok {
+		return nil
+	}
+	hi := sp.hi
+	if b.hi < hi {
+		hi = b.hi
+	}
+	step, ok := lcm(uint64(sp.stride()), uint64(b.stride()))
+	switch {
+	case !ok || step > uint64(hi)-uint64(first):
+		return []span{{lo: first, hi: first}}
+	case step > math.MaxInt: // Only first and one more value
+		return []span{{lo: first, hi: first}, {lo: int(uint64(first) + step), hi: int(uint64(first) + step)}}
+	}
+	last := uint64(first) + (uint64(hi)-uint64(first))/step*step
+	return []span{{lo: first, hi: int(last), step: int(step)}}
+}
+
+// minus returns the values of sp not in p, whose range must hold that of sp,
+// as spans in increasing order. Where the steps don't line up (E.g., "0...12"
+// less "0...12:3"), there is a span for each common multiple of the steps.
+func (sp span) minus(p span) []span {
+	if p.step <= 1 {
+		return nil
+	}
+	var result []span
+	stride := uint64(sp.stride())
+	for _, c := range sp.intersect(p) {
+		if c.lo > sp.lo {
+			before, _ := sp.clip(sp.lo, c.lo-1)
+			result = append(result, before)
+		}
+		if c.step > 0 {
+			period := uint64(c.step)
+			switch period / stride {
+			case 1:
+			case 2: // The values between those of c
+				result = append(result, spanFrom(int(uint64(c.lo)+stride), int(uint64(c.hi)-stride), period))
+			default:
+				for v := c.lo; v < c.hi; v = int(uint64(v) + period) {
+					result = append(result, spanFrom(int(uint64(v)+stride), int(uint64(v)+period-stride), stride))
+				}
+			}
+		}
+		var ok bool
+		if c.hi == sp.hi {
+			return result
+		}
+		if sp, ok = sp.clip(c.hi+1, sp.hi); !ok {
+			return result
+		}
+	}
+	return append(result, sp)
+}
+
+// spanFrom returns the span of lo through hi by step, which must be a
+// multiple of hi-lo.
+func spanFrom(lo, hi int, step uint64) span {
+	if lo == hi || step <= 1 {
+		return span{lo: lo, hi: hi}
+	}
+	return span{lo: lo, hi: hi, step: int(step)}
+}
+
+// normalize sorts spans and merges those that overlap or continue each other,
+// giving a short list of spans covering the same values whose ranges don't
+// overlap. Where spans with different steps share a range and their values
+// don't form a single sequence, the values there get spans of their own. The
+// passed slice may be reused for the result.
+func normalize(spans []span) []span {
+	if len(spans) == 0 {
+		return spans
+	}
+	var strided []span
+	runs := spans[:0]
+	for _, sp := range spans {
+		if sp.step > 1 {
+			strided = append(strided, sp)
+		} else {
+			runs = append(runs, sp)
+		}
+	}
+	runs = mergeRuns(runs)
+	if len(strided) == 0 {
+		return runs
+	}
+	var rest []span // Values of strided not in runs
+	for _, sp := range strided {
+		rest = append(rest, uncovered(sp, runs)...)
+	}
+	all := append(runs, disjoin(rest)...)
+	sort.Slice(all, func(i, j int) bool { return all[i].lo < all[j].lo })
+	return joinSpans(all)
+}
+
+// mergeRuns sorts spans of consecutive values and merges those that overlap
+// or are adjacent. The passed slice is reused for the result.
+func mergeRuns(spans []span) []span {
+	if len(spans) == 0 {
+		return spans
+	}
+	sort.Slice(spans, func(i, j int) bool { return spans[i].lo < spans[j].lo })
+	result := spans[:1]
+	for _, sp := range spans[1:] {
+		last := &result[len(result)-1]
+		if sp.lo <= last.hi || sp.lo-1 == last.hi { // Overlap or adjacent
+			if sp.hi > last.hi {
+				last.hi = sp.hi
+			}
+		} else {
+			result = append(result, sp)
+		}
+	}
+	return result
+}
+
+// disjoin returns spans covering the values of spans whose ranges don't
+// overlap, in increasing order.
+func disjoin(spans []span) []span {
+	sort.Slice(spans, func(i, j int) bool { return spans[i].lo < spans[j].lo })
+	var result []span
+	for start := 0; start < len(spans); {
+		// Gather the spans whose ranges overlap those before them.
+		end, reach := start+1, spans[start].hi
+		for ; end < len(spans) && spans[end].lo <= reach; end++ {
+			if spans[end].hi > reach {
+				reach = spans[end].hi
+			}
+		}
+		if end-start == 1 {
+			result = append(result, spans[start])
+		} else {
+			result = append(result, disjoinCluster(spans[start:end], reach)...)
+		}
+		start = end
+	}
+	return result
+}
+
+// disjoinCluster returns disjoin for spans whose ranges overlap and reach
+// up to hi. The range is cut where any span starts or ends, so each part has
+// the same spans throughout.
+func disjoinCluster(spans []span, hi int) []span {
+	cuts := make([]int, 0, 2*len(spans))
+	for _, sp := range spans {
+		cuts = append(cuts, sp.lo)
+		if sp.hi < hi {
+			cuts = append(cuts, sp.hi+1)
+		}
+	}
+	sort.Ints(cuts)
+	var result []span
+	for k, x := range cuts {
+		if k > 0 && x == cuts[k-1] {
+			continue
+		}
+		y := hi
+		for _, c := range cuts[k+1:] {
+			if c > x {
+				y = c - 1
+				break
+			}
+		}
+		var active []span
+		for _, sp := range spans {
+			if sp.lo <= x && sp.hi >= y {
+				active = append(active, sp)
+			}
+		}
+		result = append(result, unite(active, x, y)...)
+	}
+	return result
+}
+
+// unite returns the spans of the values from lo through hi of spans, whose
+// ranges all hold lo through hi, in increasing order.
+func unite(spans []span, lo, hi int) []span {
+	if len(spans) == 1 {
+		if sp, ok := spans[0].clip(lo, hi); ok {
+			return []span{sp}
+		}
+		return nil
+	}
+	if sp, found, ok := uniteCoset(spans, lo, hi); ok {
+		if !found {
+			return nil
+		}
+		return []span{sp}
+	}
+	// Give the values spans of their own.
+	var values []int
+	for _, sp := range spans {
+		if sp, ok := sp.clip(lo, hi); ok {
+			s := sp.seq()
+			for ok := true; ok; ok = s.advance() {
+				values = append(values, s.next)
+			}
+		}
+	}
+	sort.Ints(values)
+	var result []span
+	for _, v := range values {
+		if n := len(result); n > 0 && (v == result[n-1].hi || v-1 == result[n-1].hi) {
+			result[n-1].hi = v
+			continue
+		}
+		result = append(result, span{lo: v, hi: v})
+	}
+	return result
+}
+
+// uniteCoset returns the span of the values of unite if they form a single
+// sequence, reporting whether there are any. It reports false if they don't or
+// the common multiple of the steps is above maxPeriod.
+func uniteCoset(spans []span, lo, hi int) (sp span, found, ok bool) {
+	// Offsets from lo of the first value of each span from there on.
+	offs := make([]uint64, len(spans))
+	period := uint64(1)
+	for i, sp := range spans {
+		stride := uint64(sp.stride())
+		offs[i] = (stride - (uint64(lo)-uint64(sp.lo))%stride) % stride
+		if period, ok = lcm(period, stride); !ok || period > maxPeriod {
+			return span{}, false, false
+		}
+	}
+	// The values are all on a step of g from the smallest offset, and they
+	// are all the values there if they have every offset modulo the period.
+	least := offs[0]
+	for _, off := range offs[1:] {
+		if off < least {
+			least = off
+		}
+	}
+	g := period
+	for i, sp := range spans {
+		g = gcd(gcd(g, uint64(sp.stride())), offs[i]-least)
+	}
+	hit := make([]bool, period/g)
+	count := 0
+	for i, sp := range spans {
+		stride := uint64(sp.stride())
+		for off := offs[i]; off < offs[i]+period; off += stride {
+			if k := (off - least) % period / g; !hit[k] {
+				hit[k] = true
+				count++
+			}
+		}
+	}
+	if count != len(hit) {
+		return span{}, false, false
+	}
+	if least%g > uint64(hi)-uint64(lo) {
+		return span{}, false, true
+	}
+	first := int(uint64(lo) + least%g)
+	last := uint64(first) + (uint64(hi)-uint64(first))/g*g
+	return spanFrom(first, int(last), g), true, true
+}
+
+// joinSpans merges spans in increasing order whose ranges don't overlap where
+// one continues the other, as for joiner.
+func joinSpans(spans []span) []span {
+	var result []span
+	j := joiner{emit: func(sp span) { result = append(result, sp) }}
+	for _, sp := range spans {
+		j.add(sp)
+	}
+	j.flush()
+	return result
+}
+
+// joiner merges spans, added in increasing order with ranges that don't
+// overlap, where one continues the other and passes the results to emit.
+// Single values on a step are only merged once a sequence with that step
+// continues them, so specifications without steps don't gain any. A sequence
+// of two values with a step is then split into single values, which are no
+// longer to write.
+type joiner struct {
+	emit     func(span) // Destination
+	cur      span       // Span being joined if haveCur
+	singles  bool       // Whether cur is single values on its step
+	last     span       // Split span before cur if haveLast
+	haveCur  bool       // Whether cur holds a span
+	haveLast bool       // Whether last holds a span
+}
+
+// add adds sp, which must start after any earlier span ends.
+func (j *joiner) add(sp span) {
+	if sp.step > 1 && sp.hi-sp.lo == sp.step {
+		// Either value may join a different neighbour.
+		j.add(span{lo: sp.lo, hi: sp.lo})
+		j.add(span{lo: sp.hi, hi: sp.hi})
+		return
+	}
+	if !j.haveCur {
+		j.cur, j.singles, j.haveCur = sp, false, true
+		return
+	}
+	gap := uint64(sp.lo) - uint64(j.cur.hi)
+	if j.singles {
+		switch {
+		case sp.lo == sp.hi && gap == uint64(j.cur.step):
+			j.cur.hi = sp.hi
+			return
+		case sp.step == j.cur.step && gap == uint64(j.cur.step):
+			j.cur.hi, j.singles = sp.hi, false
+			return
+		}
+		// Only the last of the values may join sp.
+		last := j.cur.hi
+		j.cur.hi -= j.cur.step
+		j.release()
+		j.cur, j.singles = span{lo: last, hi: last}, false
+	}
+	if joined, ok := join(j.cur, sp); ok {
+		j.cur = joined
+		return
+	}
+	if j.cur.lo == j.cur.hi && sp.lo == sp.hi && gap > 1 && gap <= maxInt {
+		j.cur, j.singles = span{lo: j.cur.lo, hi: sp.hi, step: int(gap)}, true
+		return
+	}
+	j.release()
+	j.cur, j.singles = sp, false
+}
+
+// release passes cur on to split.
+func (j *joiner) release() {
+	if !j.singles {
+		j.split(j.cur)
+		return
+	}
+	for v := j.cur.lo; ; v += j.cur.step {
+		j.split(span{lo: v, hi: v})
+		if v == j.cur.hi {
+			return
+		}
+	}
+}
+
+// split passes sp on to emit, after any split, merging consecutive values.
+func (j *joiner) split(sp span) {
+	parts := []span{sp}
+	if sp.step > 1 && sp.hi-sp.lo == sp.step {
+		parts = []span{{lo: sp.lo, hi: sp.lo}, {lo: sp.hi, hi: sp.hi}}
+	}
+	for _, part := range parts {
+		if j.haveLast && part.step <= 1 && j.last.step <= 1 && part.lo-1 == j.last.hi {
+			j.last.hi = part.hi
+			continue
+		}
+		if j.haveLast {
+			j.emit(j.last)
+		}
+		j.last, j.haveLast = part, true
+	}
+}
+
+// flush passes on the spans held, if any.
+func (j *joiner) flush() {
+	if j.haveCur {
+		j.release()
+		j.haveCur = false
+	}
+	if j.haveLast {
+		j.emit(j.last)
+		j.haveLast = false
+	}
+}
+
+// join returns the span of the values of a and b, which starts after a ends,
+// reporting false if they are not one sequence.
+func join(a, b span) (span, bool) {
+	gap := uint64(b.lo) - uint64(a.hi)
+	switch {
+	case a.step <= 1 && b.step <= 1 && gap == 1:
+		return span{lo: a.lo, hi: b.hi}, true
+	case a.step > 1 && (b.step == a.step || b.lo == b.hi) && gap == uint64(a.step):
+		return span{lo: a.lo, hi: b.hi, step: a.step}, true
+	case a.lo == a.hi && b.step > 1 && gap == uint64(b.step):
+		return span{lo: a.lo, hi: b.hi, step: b.step}, true
+	}
+	return a, false
+}
+
+// writeSpan writes the notation for sp to b.
+func writeSpan(b *strings.Builder, sp span) {
+	b.WriteString(strconv.Itoa(sp.lo))
+	if sp.hi != sp.lo {
+		b.WriteString("...")
+		b.WriteString(strconv.Itoa(sp.hi))
+	}
+	if sp.step > 1 {
+		b.WriteByte(':')
+		b.WriteString(strconv.Itoa(sp.step))
+	}
+}
+
+// formatSpans returns a specification whose expansion is the values of spans.
+func formatSpans(spans []span) string {
+	var b strings.Builder
+	for i, sp := range spans {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		writeSpan(&b, sp)
+	}
+	return b.String()
+}
+
+// uncovered returns the values of sp not in seen as spans in increasing
+// order. The "seen" parameter must be normalized.
+func uncovered(sp span, seen []span) []span {
+	var result []span
+	i := sort.Search(len(seen), func(i int) bool { return seen[i].hi >= sp.lo })
+	for ; i < len(seen) && seen[i].lo <= sp.hi; i++ {
+		p := seen[i]
+		if p.lo > sp.lo {
+			if before, ok := sp.clip(sp.lo, p.lo-1); ok {
+				result = append(result, before)
+			}
+		}
+		lo, hi := sp.lo, sp.hi
+		if p.lo > lo {
+			lo = p.lo
+		}
+		if p.hi < hi {
+			hi = p.hi
+		}
+		if in, ok := sp.clip(lo, hi); ok {
+			result = append(result, in.minus(p)...)
+		}
+		if p.hi >= sp.hi {
+			return result
+		}
+		rest, ok := sp.clip(p.hi+1, sp.hi)
+		if !ok {
+			return result
+		}
+		sp = rest
+	}
+	return append(result, sp)
+}
+
+// covered reports whether every value of sp is in seen, which must be
+// normalized. It is checked from the ends of the spans, so the cost does not
+// depend on the number of values.
+func covered(sp span, seen []span) bool {
+	i := 0
+	for {
+		i += sort.Search(len(seen[i:]), func(k int) bool { return seen[i+k].hi >= sp.lo })
+		if i == len(seen) || seen[i].lo > sp.lo {
+			return false // The first value of sp is in a hole.
+		}
+		p := seen[i]
+		hi := sp.hi
+		if p.hi < hi {
+			hi = p.hi
+		}
+		// A span with a step holds the values of sp up to hi only if they
+		// are on its step.
+		if in, _ := sp.clip(sp.lo, hi); p.step > 1 && (!p.contains(in.lo) ||
+			(in.lo != in.hi && in.stride()%p.step != 0)) {
+			return false
+		}
+		if p.hi >= sp.hi {
+			return true
+		}
+		rest, ok := sp.clip(p.hi+1, sp.hi)
+		if !ok {
+			return true
+		}
+		sp = rest
+		i++
+	}
+}
+
+// unionSpans returns the spans of the values in a or b.
+func unionSpans(a, b []span) []span {
+	return normalize(append(append([]span(nil), a...), b...))
+}
+
+// intersectSpans returns the spans of the values in both a and b, which must
+// be normalized.
+func intersectSpans(a, b []span) []span {
+	var result []span
+	for len(a) > 0 && len(b) > 0 {
+		result = append(result, a[0].intersect(b[0])...)
+		// The span ending first can not meet any later span of the other.
+		if a[0].hi < b[0].hi {
+			a = a[1:]
+		} else {
+			b = b[1:]
+		}
+	}
+	return normalize(result)
+}
+
+// differenceSpans returns the spans of the values in a but not in b, which
+// must be normalized.
+func differenceSpans(a, b []span) []span {
+	var result []span
+	for _, sp := range a {
+		result = append(result, uncovered(sp, b)...)
+	}
+	return normalize(result)
+}
+
+// setIterator returns an Iterator through the values given by op for the sets
+// of values of a and b. The "fn" parameter is the name of the calling
+// function used in any error set.
+func setIterator(fn, a, b string, op func(a, b []span) []span) *Iterator {
+	aSeqs, err := parseSeqs(fn, a)
+	if err != nil {
+		return &Iterator{err: err}
+	}
+	bSeqs, err := parseSeqs(fn, b)
+	if err != nil {
+		return &Iterator{err: err}
+	}
+	return &Iterator{seqs: setSeqs(aSeqs, bSeqs, op)}
+}
+
+// NewUnionIterator validates the specifications and sets the state for
+// iterating through the values in either of them in increasing order without
+// duplicates. Only the items of the specifications are held in memory, so
+// the union of enormous specifications can be consumed without building it.
+//
+//   NewUnionIterator("1...3,10", "2...5") -> [1 2 3 4 5 10]
+//
+// Potential errors set in state during creation of an Iterator:
+//
+//   strconv.ErrSyntax - Error parsing integer or sequence notation
+//   strconv.ErrRange - Integer out of range
+func NewUnionIterator(a, b string) *Iterator {
+	return setIterator("NewUnionIterator", a, b, unionSpans)
+}
+
+// NewIntersectionIterator validates the specifications and sets the state for
+// iterating through the values in both of them in increasing order without
+// duplicates. Only the items of the specifications are held in memory.
+//
+//   NewIntersectionIterator("1...10", "8...20,5") -> [5 8 9 10]
+//
+// Potential errors set in state during creation of an Iterator:
+//
+//   strconv.ErrSyntax - Error parsing integer or sequence notation
+//   strconv.ErrRange - Integer out of range
+func NewIntersectionIterator(a, b string) *Iterator {
+	return setIterator("NewIntersectionIterator", a, b, intersectSpans)
+}
+
+// NewDifferenceIterator validates the specifications and sets the state for
+// iterating through the values in a but not in b in increasing order without
+// duplicates. Only the items of the specifications are held in memory.
+//
+//   NewDifferenceIterator("1...10", "3...5,9") -> [1 2 6 7 8 10]
+//
+// Potential errors set in state during creation of an Iterator:
+//
+//   strconv.ErrSyntax - Error parsing integer or sequence notation
+//   strconv.ErrRange - Integer out of range
+func NewDifferenceIterator(a, b string) *Iterator {
+	return setIterator("NewDifferenceIterator", a, b, differenceSpans)
+}
+
+// spanSeqs returns the increasing sequences covering the values of spans.
+func spanSeqs(spans []span) []seq {
+	result := make([]seq, len(spans))
+	for i, sp := range spans {
+		result[i] = sp.seq()
+	}
+	return result
+}
+
+// setSeqs returns the sequences of the values given by op for the sets of
+// values of a and b.
+func setSeqs(a, b []seq, op func(a, b []span) []span) []seq {
+	return spanSeqs(op(normalize(seqSpans(a)), normalize(seqSpans(b))))
+}
+
+// setSpec returns a specification of the values given by op for the sets of
+// values of a and b. The "fn" parameter is the name of the calling function
+// used in any error returned.
+func setSpec(fn, a, b string, op func(a, b []span) []span) (string, error) {
+	aSeqs, err := parseSeqs(fn, a)
+	if err != nil {
+		return "", err
+	}
+	bSeqs, err := parseSeqs(fn, b)
+	if err != nil {
+		return "", err
+	}
+	return formatSeqs(setSeqs(aSeqs, bSeqs, op)), nil
+}
+
+// Union returns a specification of the values in either of the passed
+// specifications in increasing order without duplicates. It is computed from
+// the items, so enormous sequences are not expanded, including those with
+// steps. Only where sequences with different steps share a range and their
+// values there don't form a single sequence are those values listed. See
+// NewUnionIterator for the values themselves.
+//
+//   Union("1...3,10", "2...5") -> "1...5,10", nil
+//   Union("0...2000000:2", "1...3") -> "0...3,4...2000000:2", nil
+//
+// Potential errors returned:
+//
+//   strconv.ErrSyntax - Error parsing integer or sequence notation
+//   strconv.ErrRange - Integer out of range
+func Union(a, b string) (string, error) {
+	return setSpec("Union", a, b, unionSpans)
+}
+
+// Intersect returns a specification of the values in both of the passed
+// specifications in increasing order without duplicates, computed from the
+// items.
+//
+//   Intersect("1...10", "8...20,5") -> "5,8...10", nil
+//
+// Potential errors returned:
+//
+//   strconv.ErrSyntax - Error parsing integer or sequence notation
+//   strconv.ErrRange - Integer out of range
+func Intersect(a, b string) (string, error) {
+	return setSpec("Intersect", a, b, intersectSpans)
+}
+
+// Subtract returns a specification of the values in a but not in b in
+// increasing order without duplicates, computed from the items. This suits
+// combining include and exclude lists.
+//
+//   Subtract("1...1000000", "3...5,9") -> "1...2,6...8,10...1000000", nil
+//
+// Potential errors returned:
+//
+//   strconv.ErrSyntax - Error parsing integer or sequence notation
+//   strconv.ErrRange - Integer out of range
+func Subtract(a, b string) (string, error) {
+	return setSpec("Subtract", a, b, differenceSpans)
+}
@@ -0,0 +1,166 @@
+// Copyright 2020 Brian E. Holland. All rights reserved.
+// The use of this source code is governed by an MIT license
+// that can be found in the LICENSE file.
+
+package intlist_test
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/brianholland99/intlist"
+	"github.com/google/go-cmp/cmp"
+)
+
+type setTest struct {
+	a, b string
+	out  []int
+	err  error
+}
+
+// checkSet runs the tests of a function creating a set Iterator.
+func checkSet(t *testing.T, name string, fn func(a, b string) *intlist.Iterator,
+	tests []setTest) {
+	t.Helper()
+	for _, test := range tests {
+		it := fn(test.a, test.b)
+		if !errors.Is(it.Err(), test.err) {
+			t.Errorf("%s(%q, %q) error = (%v) -- wanted (%v)", name, test.a, test.b, it.Err(), test.err)
+			continue
+		}
+		if test.err != nil {
+			continue
+		}
+		if out := collect(it); !cmp.Equal(out, test.out) {
+			t.Errorf("%s(%q, %q) = %v -- wanted %v", name, test.a, test.b, out, test.out)
+		}
+	}
+}
+
+var unionTests = []setTest{
+	// Good cases
+	{"1...3,10", "2...5", []int{1, 2, 3, 4, 5, 10}, nil},       // Overlap
+	{"1,5", "2...4,6", []int{1, 2, 3, 4, 5, 6}, nil},           // Touching
+	{"9...7,1", "1", []int{1, 7, 8, 9}, nil},                   // Unordered
+	{"1...2,10...11", "4...5", []int{1, 2, 4, 5, 10, 11}, nil}, // Interleaved
+	{"0...10:2", "1...11:2", []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11}, nil},
+	{"0...12:2", "12...0:3", []int{0, 2, 3, 4, 6, 8, 9, 10, 12}, nil},
+	{"0...6:2", "3", []int{0, 2, 3, 4, 6}, nil},
+	{"", "3", []int{3}, nil},
+	{"", "", []int{}, nil},
+	// Error cases
+	{"1..2", "3", nil, strconv.ErrSyntax},
+}
+
+var intersectionTests = []setTest{
+	// Good cases
+	{"1...10", "8...20,5", []int{5, 8, 9, 10}, nil},
+	{"1...3,7...9", "2...8", []int{2, 3, 7, 8}, nil}, // Several parts
+	{"1...3", "4...6", []int{}, nil},                 // Disjoint
+	{"5...1", "3,3,9", []int{3}, nil},                // Duplicates
+	{"0...30:2", "30...0:3", []int{0, 6, 12, 18, 24, 30}, nil},
+	{"0...10:2", "1...11:2", []int{}, nil},
+	{"", "3", []int{}, nil},
+	// Error cases
+	{"1", "3..4", nil, strconv.ErrSyntax},
+}
+
+var differenceTests = []setTest{
+	// Good cases
+	{"1...10", "3...5,9", []int{1, 2, 6, 7, 8, 10}, nil},
+	{"1...3,7...9", "2...8", []int{1, 9}, nil}, // Across spans
+	{"1...5", "0...9", []int{}, nil},           // All removed
+	{"1...5", "", []int{1, 2, 3, 4, 5}, nil},   // Nothing removed
+	{"4,4,1", "2", []int{1, 4}, nil},           // Duplicates
+	{"0...12:2", "0...12:3", []int{2, 4, 8, 10}, nil},
+	{"1...9", "0...10:2", []int{1, 3, 5, 7, 9}, nil},
+	{"", "3", []int{}, nil},
+	// Error cases
+	{"1..2", "3", nil, strconv.ErrSyntax},
+}
+
+func TestUnionIterator(t *testing.T) {
+	checkSet(t, "NewUnionIterator", intlist.NewUnionIterator, unionTests)
+}
+
+func TestIntersectionIterator(t *testing.T) {
+	checkSet(t, "NewIntersectionIterator", intlist.NewIntersectionIterator, intersectionTests)
+}
+
+func TestDifferenceIterator(t *testing.T) {
+	checkSet(t, "NewDifferenceIterator", intlist.NewDifferenceIterator, differenceTests)
+}
+
+// checkSetSpec runs the tests of a function giving a set specification, which
+// must expand to the values the matching Iterator gives, and of the List
+// method doing the same.
+func checkSetSpec(t *testing.T, name string, fn func(a, b string) (string, error),
+	method func(l, other *intlist.List) *intlist.List, tests []setTest) {
+	t.Helper()
+	for _, test := range tests {
+		spec, err := fn(test.a, test.b)
+		if !errors.Is(err, test.err) {
+			t.Errorf("%s(%q, %q) error = (%v) -- wanted (%v)", name, test.a, test.b, err, test.err)
+			continue
+		}
+		if test.err != nil {
+			continue
+		}
+		if out, err := intlist.ParseWithOptions(spec, steps); err != nil || !cmp.Equal(out, test.out) {
+			t.Errorf("%s(%q, %q) = %q expanding to %v -- wanted %v",
+				name, test.a, test.b, spec, out, test.out)
+		}
+		a, _ := intlist.Compile(test.a, steps)
+		b, _ := intlist.Compile(test.b, steps)
+		if list := method(a, b); list.String() != spec {
+			t.Errorf("List.%s(%q, %q) = %q -- wanted %q", name, test.a, test.b, list, spec)
+		}
+	}
+}
+
+// stridedSetTests have sequences with steps too long to expand.
+var stridedSetTests = []struct {
+	name string
+	fn   func(a, b string) (string, error)
+	a, b string
+	out  string
+}{
+	{"Union", intlist.Union, "0...200000000:2", "1", "0...1,2...200000000:2"},
+	{"Union", intlist.Union, "0...2000000:2", "1...3", "0...3,4...2000000:2"},
+	{"Union", intlist.Union, "0...1000000000:2", "1...999999999:2", "0...1000000000"},
+	{"Union", intlist.Union, "0...12:2", "0...12:3", "0,2...4,6,8...10,12"},
+	{"Union", intlist.Union, "34...14:3,-4...15:6", "-3,6...1:2", "-4...-3,2...8:2,14,16...34:3"},
+	{"Intersect", intlist.Intersect, "0...20:5", "3...15", "5...15:5"},
+	{"Intersect", intlist.Intersect, "0...1000000000000:2", "0...1000000000000:3", "0...999999999996:6"},
+	{"Intersect", intlist.Intersect, "0...1000000000:2", "1...1000000000:2", ""},
+	{"Subtract", intlist.Subtract, "0...1000000000:2", "0...1000000000:4", "2...999999998:4"},
+	{"Subtract", intlist.Subtract, "1...1000000000", "0...1000000000:2", "1...999999999:2"},
+}
+
+func TestStridedSets(t *testing.T) {
+	for _, test := range stridedSetTests {
+		if out, err := test.fn(test.a, test.b); err != nil || out != test.out {
+			t.Errorf("%s(%q, %q) = %q, (%v) -- wanted %q", test.name, test.a, test.b, out, err, test.out)
+		}
+	}
+}
+
+func TestUnion(t *testing.T) {
+	checkSetSpec(t, "Union", intlist.Union, (*intlist.List).Union, unionTests)
+	if out, _ := intlist.Union("1...3,10", "2...5"); out != "1...5,10" {
+		t.Errorf("Union(%q, %q) = %q -- wanted %q", "1...3,10", "2...5", out, "1...5,10")
+	}
+}
+
+func TestIntersect(t *testing.T) {
+	checkSetSpec(t, "Intersect", intlist.Intersect, (*intlist.List).Intersect, intersectionTests)
+}
+
+func TestSubtract(t *testing.T) {
+	checkSetSpec(t, "Subtract", intlist.Subtract, (*intlist.List).Subtract, differenceTests)
+	if out, _ := intlist.Subtract("1...1000000", "3...5,9"); out != "1...2,6...8,10...1000000" {
+		t.Errorf("Subtract(%q, %q) = %q -- wanted %q", "1...1000000", "3...5,9",
+			out, "1...2,6...8,10...1000000")
+	}
+}
@@ -0,0 +1,105 @@
+// Copyright 2020 Brian E. Holland. All rights reserved.
+// The use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package intlist
+
+import (
+	"math/bits"
+	"math/rand"
+)
+
+// feistelRounds is the number of rounds used by a permutation. Six rounds are
+// plenty to hide the structure of the positions for scan-order purposes.
+const feistelRounds = 6
+
+// permutation is a pseudo-random bijection on [0, n) built from a Feistel
+// network over the smallest even number of bits covering n. Values outside of
+// [0, n) are walked through the cycle until they land inside the range.
+type permutation struct {
+	n    uint64                // Size of the domain
+	half uint                  // Number of bits in each half of the network
+	keys [feistelRounds]uint64 // Round keys
+}
+
+// newPermutation returns a random permutation of [0, n) keyed from r.
+func newPermutation(n uint64, r *rand.Rand) *permutation {
+	p := &permutation{n: n}
+	if n > 1 {
+		p.half = uint(bits.Len64(n-1)+1) / 2
+	}
+	for k := range p.keys {
+		p.keys[k] = r.Uint64()
+	}
+	return p
+}
+
+// at returns the position that x (which must be less than p.n) maps to.
+func (p *permutation) at(x uint64) uint64 {
+	for {
+		x = p.encrypt(x)
+		if x < p.n {
+			return x
+		}
+	}
+}
+
+// encrypt applies the Feistel network to x.
+func (p *permutation) encrypt(x uint64) uint64 {
+	mask := uint64(1)<<p.half - 1
+	left, right := x>>p.half, x&mask
+	for _, key := range p.keys {
+		left, right = right, left^(mix(right^key)&mask)
+	}
+	return left<<p.half | right
+}
+
+// mix is the splitmix64 finalizer, used as the round function.
+func mix(z uint64) uint64 {
+	z = (z ^ z>>30) * 0xbf58476d1ce4e5b9
+	z = (z ^ z>>27) * 0x94d049bb133111eb
+	return z ^ z>>31
+}
+
+// NewShuffledIterator validates the specification and sets the state for
+// iterating through every value of the expansion exactly once in
+// pseudo-random order.
+//
+// The order is a permutation of the positions of the expansion chosen using
+// "r", so only memory proportional to the number of items in "spec" is used
+// regardless of the number of values. A value listed more than once in the
+// specification is returned more than once. The "r" parameter must not be nil.
+//
+//   NewShuffledIterator("1...5", r) -> [4 1 5 3 2]
+//
+// Potential errors set in state during creation of an Iterator:
+//
+//   strconv.ErrSyntax - Error parsing integer or sequence notation
+//   strconv.ErrRange - Integer out of range or too many values to count
+func NewShuffledIterator(spec string, r *rand.Rand) *Iterator {
+	const fn = "NewShuffledIterator"
+	seqs, err := parseSeqs(fn, spec)
+	if err != nil {
+		return &Iterator{err: err}
+	}
+	cum, err := cumLens(fn, spec, seqs)
+	if err != nil {
+		return &Iterator{err: err}
+	}
+	var total uint64
+	if len(cum) > 0 {
+		total = cum[len(cum)-1]
+	}
+	perm := newPermutation(total, r)
+	var pos uint64 // Number of values returned so far
+	return &Iterator{
+		gen: func() (int, bool) {
+			if pos == total {
+				return 0, false
+			}
+			val := valueAt(seqs, cum, perm.at(pos))
+			pos++
+			return val, true
+		},
+	}
+}
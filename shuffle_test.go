@@ -0,0 +1,57 @@
+// Copyright 2020 Brian E. Holland. All rights reserved.
+// The use of this source code is governed by an MIT license
+// that can be found in the LICENSE file.
+
+package intlist_test
+
+import (
+	"errors"
+	"math/rand"
+	"sort"
+	"strconv"
+	"testing"
+
+	"github.com/brianholland99/intlist"
+	"github.com/google/go-cmp/cmp"
+)
+
+var shuffleTests = []parseTest{
+	// Good cases
+	{"1234", []int{1234}, nil}, // Single Int
+	{"", []int{}, nil},         // Empty list
+	{"1...3,7,5...3,9", []int{1, 2, 3, 7, 5, 4, 3, 9}, nil}, // Duplicate 3
+	{"0...999", nil, nil}, // Expected below
+	// Error cases
+	{"3.5,12", nil, strconv.ErrSyntax}, // Non-integer
+}
+
+// Each value of the expansion must be visited exactly once.
+func TestShuffledIterator(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for _, test := range shuffleTests {
+		it := intlist.NewShuffledIterator(test.in, r)
+		if !errors.Is(it.Err(), test.err) {
+			t.Errorf("NewShuffledIterator(%q) error = (%v) -- wanted (%v)",
+				test.in, it.Err(), test.err)
+			continue
+		}
+		if test.err != nil {
+			continue
+		}
+		want, _ := intlist.Parse(test.in)
+		out := []int{}
+		for {
+			val, err := it.Next()
+			if err == intlist.ErrDone {
+				break
+			}
+			out = append(out, val)
+		}
+		sort.Ints(out)
+		sort.Ints(want)
+		if !cmp.Equal(out, want) {
+			t.Errorf("NewShuffledIterator(%q) sorted = %v -- wanted %v",
+				test.in, out, want)
+		}
+	}
+}
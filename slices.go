@@ -0,0 +1,148 @@
+// Copyright 2020 Brian E. Holland. All rights reserved.
+// The use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package intlist
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Select returns the elements of s at the indices (origin 0) represented by
+// the passed specification. The order of the specification is kept along with
+// any repeats. The result does not share storage with s.
+//
+//   Select([]string{"a", "b", "c", "d"}, "3,0...1,1") -> [d a b b], nil
+//
+// Potential errors returned:
+//
+//   strconv.ErrSyntax - Error parsing integer or sequence notation
+//   strconv.ErrRange - Integer out of range
+//   ErrOutOfBounds - Index outside of s
+func Select[T any](s []T, spec string) ([]T, error) {
+	seqs, err := parseIndices("Select", spec, len(s))
+	if err != nil {
+		return nil, err
+	}
+	it := &Iterator{seqs: seqs}
+	result := []T{}
+	for {
+		idx, err := it.Next()
+		if err == ErrDone {
+			break
+		}
+		result = append(result, s[idx])
+	}
+	return result, nil
+}
+
+// parseIndices parses spec as indices (origin 0) of a slice of length n. The
+// "fn" parameter is the name of the calling function used in any error
+// returned.
+func parseIndices(fn, spec string, n int) ([]seq, error) {
+	c := &config{
+		features: extensions,
+		bounded:  true,
+		lo:       0,
+		hi:       n - 1,
+	}
+	return parseSpec(fn, spec, c)
+}
+
+// Delete removes the elements of s at the indices (origin 0) represented by
+// the passed specification and returns the modified slice. The remaining
+// elements keep their order. Indices may be given in any order and more than
+// once. Like an append, the result uses the storage of s, whose elements past
+// the new length are zeroed.
+//
+//   Delete([]string{"a", "b", "c", "d"}, "3,0") -> [b c], nil
+//
+// Potential errors returned are those of Select, in which case s is not
+// modified.
+func Delete[T any](s []T, spec string) ([]T, error) {
+	return keepIndices("Delete", s, spec, false)
+}
+
+// Keep retains only the elements of s at the indices (origin 0) represented by
+// the passed specification and returns the modified slice. The retained
+// elements keep their order in s, unlike with Select. Indices may be given in
+// any order and more than once. Like an append, the result uses the storage of
+// s, whose elements past the new length are zeroed.
+//
+//   Keep([]string{"a", "b", "c", "d"}, "3,0") -> [a d], nil
+//
+// Potential errors returned are those of Select, in which case s is not
+// modified.
+func Keep[T any](s []T, spec string) ([]T, error) {
+	return keepIndices("Keep", s, spec, true)
+}
+
+// keepIndices implements Delete and Keep by retaining the elements of s whose
+// index is selected by spec if keep is true and not selected otherwise. The
+// "fn" parameter is the name of the calling function used in any error
+// returned.
+func keepIndices[T any](fn string, s []T, spec string, keep bool) ([]T, error) {
+	seqs, err := parseIndices(fn, spec, len(s))
+	if err != nil {
+		return nil, err
+	}
+	spans := normalize(seqSpans(seqs))
+	n := 0 // Number of elements retained
+	for i := range s {
+		for len(spans) > 0 && spans[0].hi < i {
+			spans = spans[1:]
+		}
+		selected := len(spans) > 0 && spans[0].contains(i)
+		if selected == keep {
+			s[n] = s[i]
+			n++
+		}
+	}
+	var zero T
+	for i := n; i < len(s); i++ {
+		s[i] = zero
+	}
+	return s[:n], nil
+}
+
+// ValidateIndices checks that every value represented by the passed
+// specification is a valid index (origin 0) into a collection of length n.
+// All of the offending items are reported in the returned *IndexError.
+//
+//   ValidateIndices("0...3,2", 4) -> nil
+//   ValidateIndices("4,1,-1...0", 4) -> &IndexError{Items: ["4" "-1...0"]}
+//
+// Potential errors returned:
+//
+//   strconv.ErrSyntax - Error parsing integer or sequence notation
+//   strconv.ErrRange - Integer out of range
+//   *IndexError - Items with values outside [0, n), wrapping ErrOutOfBounds
+//   ErrInvalidArg - The "n" parameter is negative
+func ValidateIndices(spec string, n int) error {
+	const fn = "ValidateIndices"
+	if n < 0 {
+		return fmt.Errorf("intlist.%s: length %d: %w", fn, n, ErrInvalidArg)
+	}
+	var bad []string // Offending items
+	c := &config{
+		features: extensions,
+		trace: func(e TraceEvent) {
+			lo, hi := e.First, e.Last
+			if lo > hi {
+				lo, hi = hi, lo
+			}
+			excluding := strings.HasPrefix(e.Item, "!") // Removes values instead
+			if e.Err == nil && !excluding && (lo < 0 || hi >= n) {
+				bad = append(bad, e.Item)
+			}
+		},
+	}
+	if _, err := parseSpec(fn, spec, c); err != nil {
+		return err
+	}
+	if len(bad) > 0 {
+		return &IndexError{Func: fn, Len: n, Items: bad}
+	}
+	return nil
+}
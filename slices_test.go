@@ -0,0 +1,142 @@
+// Copyright 2020 Brian E. Holland. All rights reserved.
+// The use of this source code is governed by an MIT license
+// that can be found in the LICENSE file.
+
+package intlist_test
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/brianholland99/intlist"
+	"github.com/google/go-cmp/cmp"
+)
+
+var letters = []string{"a", "b", "c", "d"}
+
+type sliceTest struct {
+	in  string
+	out []string
+	err error
+}
+
+var selectTests = []sliceTest{
+	// Good cases
+	{"3,0...1,1", []string{"d", "a", "b", "b"}, nil},
+	{"3...0", []string{"d", "c", "b", "a"}, nil},
+	{"", []string{}, nil},
+	// Error cases
+	{"4", nil, intlist.ErrOutOfBounds},
+	{"-1...1", nil, intlist.ErrOutOfBounds},
+	{"a", nil, strconv.ErrSyntax},
+}
+
+// checkSlice runs the tests of a function selecting from letters.
+func checkSlice(t *testing.T, name string,
+	fn func([]string, string) ([]string, error), tests []sliceTest) {
+	t.Helper()
+	for _, test := range tests {
+		out, err := fn(letters, test.in)
+		if !cmp.Equal(out, test.out) || !errors.Is(err, test.err) {
+			t.Errorf("%s(%q, %q) = (%q), (%v) -- wanted (%q), (%v)",
+				name, letters, test.in, out, err, test.out, test.err)
+		}
+	}
+}
+
+func TestSelect(t *testing.T) {
+	checkSlice(t, "Select", intlist.Select[string], selectTests)
+}
+
+func TestSelectEmptySlice(t *testing.T) {
+	out, err := intlist.Select([]int{}, "0")
+	if out != nil || !errors.Is(err, intlist.ErrOutOfBounds) {
+		t.Errorf("Select(%v, %q) = (%v), (%v) -- wanted (nil), (%v)",
+			[]int{}, "0", out, err, intlist.ErrOutOfBounds)
+	}
+}
+
+var deleteTests = []sliceTest{
+	// Good cases
+	{"3,0", []string{"b", "c"}, nil},
+	{"1...2,2,1", []string{"a", "d"}, nil}, // Repeats
+	{"0...3", []string{}, nil},
+	{"3...0:2", []string{"a", "c"}, nil},
+	{"", []string{"a", "b", "c", "d"}, nil},
+	// Error cases
+	{"4", nil, intlist.ErrOutOfBounds},
+	{"1,x", nil, strconv.ErrSyntax},
+}
+
+var keepTests = []sliceTest{
+	// Good cases
+	{"3,0", []string{"a", "d"}, nil}, // Order of s is kept
+	{"2...1,1", []string{"b", "c"}, nil},
+	{"0...3", []string{"a", "b", "c", "d"}, nil},
+	{"3...0:2", []string{"b", "d"}, nil},
+	{"", []string{}, nil},
+	// Error cases
+	{"-1", nil, intlist.ErrOutOfBounds},
+	{"1...", nil, strconv.ErrSyntax},
+}
+
+// onCopy returns fn applied to a copy of the passed slice so that the tests
+// can share letters.
+func onCopy(fn func([]string, string) ([]string, error)) func([]string, string) ([]string, error) {
+	return func(s []string, spec string) ([]string, error) {
+		return fn(append([]string(nil), s...), spec)
+	}
+}
+
+func TestDelete(t *testing.T) {
+	checkSlice(t, "Delete", onCopy(intlist.Delete[string]), deleteTests)
+}
+
+func TestKeep(t *testing.T) {
+	checkSlice(t, "Keep", onCopy(intlist.Keep[string]), keepTests)
+}
+
+func TestDeleteInPlace(t *testing.T) {
+	s := []string{"a", "b", "c"}
+	out, err := intlist.Delete(s, "0")
+	want := []string{"b", "c", ""}
+	if err != nil || !cmp.Equal(s, want) || &out[0] != &s[0] {
+		t.Errorf("Delete(s, %q) left s = %q, (%v) -- wanted %q sharing storage, (nil)",
+			"0", s, err, want)
+	}
+}
+
+var validateIndicesTests = []struct {
+	in  string
+	n   int
+	bad []string // Items reported in an *IndexError
+	err error
+}{
+	// Good cases
+	{"0...3,2", 4, nil, nil},
+	{"3...0", 4, nil, nil},
+	{"", 0, nil, nil},
+	{"0...3,!10", 4, nil, nil}, // Exclusions are not indices.
+	// Error cases
+	{"4,1,-1...0", 4, []string{"4", "-1...0"}, intlist.ErrOutOfBounds},
+	{"0", 0, []string{"0"}, intlist.ErrOutOfBounds},
+	{"0...9,!5...20", 4, []string{"0...9"}, intlist.ErrOutOfBounds},
+	{"5,1..2", 4, nil, strconv.ErrSyntax}, // Syntax errors come first
+	{"0", -1, nil, intlist.ErrInvalidArg},
+}
+
+func TestValidateIndices(t *testing.T) {
+	for _, test := range validateIndicesTests {
+		err := intlist.ValidateIndices(test.in, test.n)
+		var bad []string
+		var indexErr *intlist.IndexError
+		if errors.As(err, &indexErr) {
+			bad = indexErr.Items
+		}
+		if !errors.Is(err, test.err) || !cmp.Equal(bad, test.bad) {
+			t.Errorf("ValidateIndices(%q, %d) = (%v) -- wanted (%v) with items %q",
+				test.in, test.n, err, test.err, test.bad)
+		}
+	}
+}
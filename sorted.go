@@ -0,0 +1,71 @@
+// Copyright 2020 Brian E. Holland. All rights reserved.
+// The use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package intlist
+
+import "container/heap"
+
+// seqHeap is a min-heap of increasing sequences ordered by their next value.
+type seqHeap []seq
+
+func (h seqHeap) Len() int            { return len(h) }
+func (h seqHeap) Less(i, j int) bool  { return h[i].next < h[j].next }
+func (h seqHeap) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
+func (h *seqHeap) Push(x interface{}) { *h = append(*h, x.(seq)) }
+func (h *seqHeap) Pop() interface{} {
+	old := *h
+	s := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return s
+}
+
+// ascending returns s flipped to be increasing if it is decreasing.
+func ascending(s seq) seq {
+	if s.step < 0 {
+		return s.reversed()
+	}
+	return s
+}
+
+// NewSortedIterator validates the specification and sets the state for
+// iterating through the values of its expansion in ascending order.
+//
+// The values are merged from the sequences as they are retrieved, so memory
+// proportional to the number of items in "spec" is used regardless of the
+// number of values. Values listed more than once are returned more than once.
+// This allows unordered human-written specifications to feed consumers that
+// need sorted input.
+//
+//   NewSortedIterator("10...8,1,9...12") -> [1 8 9 9 10 10 11 12]
+//
+// Potential errors set in state during creation of an Iterator:
+//
+//   strconv.ErrSyntax - Error parsing integer or sequence notation
+//   strconv.ErrRange - Integer out of range
+func NewSortedIterator(spec string) *Iterator {
+	seqs, err := parseSeqs("NewSortedIterator", spec)
+	if err != nil {
+		return &Iterator{err: err}
+	}
+	h := make(seqHeap, len(seqs))
+	for i, s := range seqs {
+		h[i] = ascending(s)
+	}
+	heap.Init(&h)
+	return &Iterator{
+		gen: func() (int, bool) {
+			if len(h) == 0 {
+				return 0, false
+			}
+			item := &h[0] // Sequence holding the smallest value
+			val := item.next
+			if !item.advance() {
+				heap.Pop(&h)
+			} else {
+				heap.Fix(&h, 0)
+			}
+			return val, true
+		},
+	}
+}
@@ -0,0 +1,42 @@
+// Copyright 2020 Brian E. Holland. All rights reserved.
+// The use of this source code is governed by an MIT license
+// that can be found in the LICENSE file.
+
+package intlist_test
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/brianholland99/intlist"
+	"github.com/google/go-cmp/cmp"
+)
+
+var sortedTests = []parseTest{
+	// Good cases
+	{"10...8,1,9...12", []int{1, 8, 9, 9, 10, 10, 11, 12}, nil}, // Overlaps
+	{"5,4,3", []int{3, 4, 5}, nil},                              // Reversed
+	{"-1...2", []int{-1, 0, 1, 2}, nil},                         // Sorted
+	{"", []int{}, nil},                                          // Empty list
+	// Error cases
+	{"3.9...5", nil, strconv.ErrSyntax}, // Seq. start - non-integer
+}
+
+func TestSortedIterator(t *testing.T) {
+	for _, test := range sortedTests {
+		it := intlist.NewSortedIterator(test.in)
+		if !errors.Is(it.Err(), test.err) {
+			t.Errorf("NewSortedIterator(%q) error = (%v) -- wanted (%v)",
+				test.in, it.Err(), test.err)
+			continue
+		}
+		if test.err != nil {
+			continue
+		}
+		if out := collect(it); !cmp.Equal(out, test.out) {
+			t.Errorf("NewSortedIterator(%q) = %v -- wanted %v",
+				test.in, out, test.out)
+		}
+	}
+}
@@ -0,0 +1,120 @@
+// Copyright 2020 Brian E. Holland. All rights reserved.
+// The use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package intlist
+
+import "strconv"
+
+// Mean returns the average of the values of the passed specification,
+// counting repeated values each time they appear. It is computed from the
+// sequences, so the cost does not depend on the number of values.
+//
+//   Mean("1...10,20") -> 6.8181..., nil
+//
+// Potential errors returned:
+//
+//   strconv.ErrSyntax - Error parsing integer or sequence notation
+//   strconv.ErrRange - Integer out of range or too many values to count
+//   ErrEmpty - No values to average
+func Mean(spec string) (float64, error) {
+	const fn = "Mean"
+	seqs, total, err := parseStats(fn, spec)
+	if err != nil {
+		return 0, err
+	}
+	var sum float64
+	for _, s := range seqs {
+		// The mean of a run is the mean of its endpoints.
+		sum += float64(s.len()) * (float64(s.next) + float64(s.last)) / 2
+	}
+	return sum / float64(total), nil
+}
+
+// Median returns the middle value of the passed specification in sorted
+// order, or the average of the two middle values if there is an even number
+// of values. Repeated values are counted each time they appear. It is
+// computed from the sequences by searching for the order statistics, so the
+// cost does not depend on the number of values.
+//
+//   Median("1...10,20") -> 6, nil
+//   Median("1...10") -> 5.5, nil
+//
+// Potential errors returned:
+//
+//   strconv.ErrSyntax - Error parsing integer or sequence notation
+//   strconv.ErrRange - Integer out of range or too many values to count
+//   ErrEmpty - No values to take the median of
+func Median(spec string) (float64, error) {
+	const fn = "Median"
+	seqs, total, err := parseStats(fn, spec)
+	if err != nil {
+		return 0, err
+	}
+	for i, s := range seqs {
+		seqs[i] = ascending(s)
+	}
+	mid := kthSmallest(seqs, (total-1)/2)
+	if total%2 == 1 {
+		return float64(mid), nil
+	}
+	return (float64(mid) + float64(kthSmallest(seqs, total/2))) / 2, nil
+}
+
+// parseStats parses spec and returns its sequences along with the number of
+// values, which must not be 0. The "fn" parameter is the name of the calling
+// function used in any error returned.
+func parseStats(fn, spec string) ([]seq, uint64, error) {
+	seqs, err := parseSeqs(fn, spec)
+	if err != nil {
+		return nil, 0, err
+	}
+	cum, err := cumLens(fn, spec, seqs)
+	if err != nil {
+		return nil, 0, err
+	}
+	if len(cum) == 0 {
+		return nil, 0, &strconv.NumError{Func: fn, Num: spec, Err: ErrEmpty}
+	}
+	return seqs, cum[len(cum)-1], nil
+}
+
+// kthSmallest returns the k-th smallest value (origin 0) of the increasing
+// sequences seqs, counting repeats, where k is less than the number of values.
+func kthSmallest(seqs []seq, k uint64) int {
+	lo, hi := seqs[0].next, seqs[0].last
+	for _, s := range seqs[1:] {
+		if s.next < lo {
+			lo = s.next
+		}
+		if s.last > hi {
+			hi = s.last
+		}
+	}
+	// Find the smallest value with more than k values at or below it.
+	for lo < hi {
+		mid := int(uint64(lo) + (uint64(hi)-uint64(lo))/2)
+		if countAtMost(seqs, mid) > k {
+			hi = mid
+		} else {
+			lo = mid + 1
+		}
+	}
+	return lo
+}
+
+// countAtMost returns the number of values of the increasing sequences seqs
+// that are less than or equal to x.
+func countAtMost(seqs []seq, x int) uint64 {
+	var count uint64
+	for _, s := range seqs {
+		if fl, ok := s.floor(x); ok {
+			if s.step == 0 {
+				count += s.len() // Any copies too
+			} else {
+				count += (uint64(fl)-uint64(s.next))/s.stride() + 1
+			}
+		}
+	}
+	return count
+}
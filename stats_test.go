@@ -0,0 +1,67 @@
+// Copyright 2020 Brian E. Holland. All rights reserved.
+// The use of this source code is governed by an MIT license
+// that can be found in the LICENSE file.
+
+package intlist_test
+
+import (
+	"errors"
+	"math"
+	"strconv"
+	"testing"
+
+	"github.com/brianholland99/intlist"
+)
+
+type statsTest struct {
+	in  string
+	out float64
+	err error
+}
+
+// checkStats runs the tests of a statistic of a specification.
+func checkStats(t *testing.T, name string, fn func(string) (float64, error),
+	tests []statsTest) {
+	t.Helper()
+	for _, test := range tests {
+		out, err := fn(test.in)
+		if math.Abs(out-test.out) > 1e-9 || !errors.Is(err, test.err) {
+			t.Errorf("%s(%q) = (%v), (%v) -- wanted (%v), (%v)",
+				name, test.in, out, err, test.out, test.err)
+		}
+	}
+}
+
+var meanTests = []statsTest{
+	// Good cases
+	{"1...10,20", 75.0 / 11, nil},
+	{"10...1", 5.5, nil},                       // Decreasing
+	{"1...3,3", 2.25, nil},                     // Repeats counted
+	{"-5...5", 0, nil},                         // Negatives
+	{"2147483647,2147483647", 2147483647, nil}, // No overflow of the sum
+	// Error cases
+	{"", 0, intlist.ErrEmpty},
+	{"1..3", 0, strconv.ErrSyntax},
+}
+
+var medianTests = []statsTest{
+	// Good cases
+	{"1...10,20", 6, nil},             // Odd count
+	{"1...10", 5.5, nil},              // Even count
+	{"1...3,2...4", 2.5, nil},         // Overlap counted twice
+	{"100,1,50", 50, nil},             // Unordered
+	{"5,5,5,1", 5, nil},               // Repeats
+	{"-3...-1,1000000000", -1.5, nil}, // Far outlier
+	{"7", 7, nil},
+	// Error cases
+	{"", 0, intlist.ErrEmpty},
+	{"1..3", 0, strconv.ErrSyntax},
+}
+
+func TestMean(t *testing.T) {
+	checkStats(t, "Mean", intlist.Mean, meanTests)
+}
+
+func TestMedian(t *testing.T) {
+	checkStats(t, "Median", intlist.Median, medianTests)
+}
@@ -0,0 +1,280 @@
+// Copyright 2020 Brian E. Holland. All rights reserved.
+// The use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package intlist
+
+import (
+	"bufio"
+	"bytes"
+	"container/heap"
+	"encoding/binary"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// normalizeChunk is the number of spans NormalizeStream holds in memory before
+// sorting them out to a temporary file.
+var normalizeChunk = 1 << 16
+
+// NormalizeStream reads a specification from r and writes the canonical
+// specification for the same set of values to w.
+//
+// The canonical specification lists the values in increasing order with no
+// duplicates as sequences that neither overlap nor touch, keeping any steps
+// as for Canonicalize. Whitespace around items is allowed. Memory is bounded
+// regardless of the size of the specification by sorting chunks of it out to
+// temporary files that are then merged, so this suits batch jobs with
+// specifications too large to hold in memory as strings. Only where sequences
+// with different steps share a range are their values there held.
+//
+//   NormalizeStream(strings.NewReader("9,3...1,4,2...6\n"), w) ->
+//       "1...6,9" written to w, nil
+//
+// Potential errors returned:
+//
+//   strconv.ErrSyntax - Error parsing integer or sequence notation
+//   strconv.ErrRange - Integer out of range
+//   Errors reading r, writing to w or using temporary files
+func NormalizeStream(r io.Reader, w io.Writer) error {
+	const fn = "NormalizeStream"
+	// Exclusions are left out, as an item can't remove values from the others
+	// when each is parsed on its own.
+	c := &config{lenient: true, features: extensions &^ FeatureExclusion}
+	sc := bufio.NewScanner(r)
+	sc.Split(itemSplitter())
+	var chunk []span     // Spans not yet sorted out to a file
+	var files []*os.File // Temporary files of sorted spans
+	defer func() {
+		for _, f := range files {
+			f.Close()
+			os.Remove(f.Name())
+		}
+	}()
+	var items int  // Number of items read
+	var blank bool // Whether a blank item was read
+	for sc.Scan() {
+		item := sc.Text()
+		items++
+		seqs, err := parseSpec(fn, item, c)
+		if err != nil {
+			return err
+		}
+		if len(seqs) == 0 {
+			blank = true // Only allowed for a whitespace-only specification.
+		}
+		if blank && items > 1 {
+			return &strconv.NumError{Func: fn, Num: "", Err: strconv.ErrSyntax}
+		}
+		if len(seqs) == 0 {
+			continue
+		}
+		chunk = append(chunk, seqs[0].spans()...)
+		if len(chunk) >= normalizeChunk {
+			f, err := writeChunk(normalize(chunk))
+			if f != nil {
+				files = append(files, f)
+			}
+			if err != nil {
+				return err
+			}
+			chunk = chunk[:0]
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return err
+	}
+	chunk = normalize(chunk)
+	bw := bufio.NewWriter(w)
+	out := newSpanWriter(bw)
+	if len(files) == 0 {
+		for _, sp := range chunk {
+			out.add(sp)
+		}
+	} else {
+		f, err := writeChunk(chunk)
+		if f != nil {
+			files = append(files, f)
+		}
+		if err != nil {
+			return err
+		}
+		if err := mergeChunks(files, out); err != nil {
+			return err
+		}
+	}
+	out.flush()
+	if out.err != nil {
+		return out.err
+	}
+	return bw.Flush()
+}
+
+// itemSplitter returns a bufio.SplitFunc breaking a specification into its
+// comma-separated items. Unlike splitting lines, a final empty item after a
+// trailing comma is returned so that it can be reported.
+func itemSplitter() bufio.SplitFunc {
+	afterComma := false // Whether the previous item ended with a comma
+	return func(data []byte, atEOF bool) (int, []byte, error) {
+		if i := bytes.IndexByte(data, ','); i >= 0 {
+			afterComma = true
+			return i + 1, data[:i], nil
+		}
+		if !atEOF {
+			return 0, nil, nil // Need more data.
+		}
+		if len(data) > 0 || afterComma {
+			afterComma = false
+			return len(data), data, bufio.ErrFinalToken
+		}
+		return 0, nil, nil
+	}
+}
+
+// writeChunk writes spans to a new temporary file and rewinds it for reading.
+// The file is returned even on error so that it can be removed.
+func writeChunk(spans []span) (*os.File, error) {
+	f, err := os.CreateTemp("", "intlist-*")
+	if err != nil {
+		return nil, err
+	}
+	bw := bufio.NewWriter(f)
+	for _, sp := range spans {
+		fields := [3]int64{int64(sp.lo), int64(sp.hi), int64(sp.step)}
+		if err := binary.Write(bw, binary.BigEndian, fields); err != nil {
+			return f, err
+		}
+	}
+	if err := bw.Flush(); err != nil {
+		return f, err
+	}
+	_, err = f.Seek(0, io.SeekStart)
+	return f, err
+}
+
+// chunkHead is the next span of a temporary file being merged.
+type chunkHead struct {
+	sp span          // Next span from the file
+	r  *bufio.Reader // Rest of the file
+}
+
+// chunkHeap is a min-heap of chunkHeads ordered by the start of the span.
+type chunkHeap []chunkHead
+
+func (h chunkHeap) Len() int            { return len(h) }
+func (h chunkHeap) Less(i, j int) bool  { return h[i].sp.lo < h[j].sp.lo }
+func (h chunkHeap) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
+func (h *chunkHeap) Push(x interface{}) { *h = append(*h, x.(chunkHead)) }
+func (h *chunkHeap) Pop() interface{} {
+	old := *h
+	head := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return head
+}
+
+// readSpan reads the next span written by writeChunk. It returns io.EOF when
+// there are no more spans.
+func readSpan(r io.Reader) (span, error) {
+	var fields [3]int64
+	if err := binary.Read(r, binary.BigEndian, &fields); err != nil {
+		return span{}, err
+	}
+	return span{lo: int(fields[0]), hi: int(fields[1]), step: int(fields[2])}, nil
+}
+
+// mergeChunks merges the sorted spans of files into out.
+func mergeChunks(files []*os.File, out *spanWriter) error {
+	h := chunkHeap{}
+	for _, f := range files {
+		r := bufio.NewReader(f)
+		sp, err := readSpan(r)
+		if err == io.EOF {
+			continue
+		} else if err != nil {
+			return err
+		}
+		h = append(h, chunkHead{sp: sp, r: r})
+	}
+	heap.Init(&h)
+	for len(h) > 0 {
+		out.add(h[0].sp)
+		sp, err := readSpan(h[0].r)
+		if err == io.EOF {
+			heap.Pop(&h)
+			continue
+		} else if err != nil {
+			return err
+		}
+		h[0].sp = sp
+		heap.Fix(&h, 0)
+	}
+	return nil
+}
+
+// spanWriter writes the notation for spans added in increasing order of
+// their start, normalizing those whose ranges overlap. The first write error
+// is kept in err.
+type spanWriter struct {
+	w       io.Writer // Destination
+	pending []span    // Spans whose ranges overlap, not yet normalized
+	reach   int       // Largest value of pending
+	limit   int       // Length of pending at which to normalize it
+	j       joiner    // Normalized spans not yet written
+	wrote   bool      // Whether an item was written
+	err     error     // First write error
+}
+
+// newSpanWriter returns a spanWriter writing to w.
+func newSpanWriter(w io.Writer) *spanWriter {
+	sw := &spanWriter{w: w, limit: normalizeChunk}
+	sw.j.emit = sw.write
+	return sw
+}
+
+// add adds sp, whose start must not be less than that of any earlier span.
+func (sw *spanWriter) add(sp span) {
+	if len(sw.pending) > 0 && sp.lo > sw.reach {
+		sw.resolve()
+	}
+	if len(sw.pending) == 0 || sp.hi > sw.reach {
+		sw.reach = sp.hi
+	}
+	sw.pending = append(sw.pending, sp)
+	if len(sw.pending) >= sw.limit {
+		// Spans within others are dropped, so this stays small unless steps
+		// interleave.
+		sw.pending = normalize(sw.pending)
+		sw.limit = len(sw.pending) + normalizeChunk
+	}
+}
+
+// resolve passes the normalized pending spans on to be written.
+func (sw *spanWriter) resolve() {
+	for _, sp := range normalize(sw.pending) {
+		sw.j.add(sp)
+	}
+	sw.pending = sw.pending[:0]
+	sw.limit = normalizeChunk
+}
+
+// flush writes the spans held, if any.
+func (sw *spanWriter) flush() {
+	sw.resolve()
+	sw.j.flush()
+}
+
+// write writes the notation for sp.
+func (sw *spanWriter) write(sp span) {
+	if sw.err != nil {
+		return
+	}
+	var b strings.Builder
+	if sw.wrote {
+		b.WriteByte(',')
+	}
+	writeSpan(&b, sp)
+	_, sw.err = io.WriteString(sw.w, b.String())
+	sw.wrote = true
+}
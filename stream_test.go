@@ -0,0 +1,62 @@
+// Copyright 2020 Brian E. Holland. All rights reserved.
+// The use of this source code is governed by an MIT license
+// that can be found in the LICENSE file.
+
+package intlist_test
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/brianholland99/intlist"
+)
+
+var normalizeStreamTests = []transformTest{
+	// Good cases
+	{"9,3...1,4,2...6\n", "1...6,9", nil},               // Overlaps
+	{"10...8, 1, 7,12...11", "1,7...12", nil},           // Adjacent
+	{"5,5,5", "5", nil},                                 // Duplicates
+	{"", "", nil},                                       // Empty list
+	{" \n", "", nil},                                    // Whitespace only
+	{"-3...-1,0...2", "-3...2", nil},                    // Negatives
+	{"0...200000000:2,1", "0...1,2...200000000:2", nil}, // Steps
+	{"1...9:2,0...10:2", "0...10", nil},
+	{"0...12:3,1...13:3,2...14:3", "0...14", nil},
+	{"0...12:2,0...12:3", "0,2...4,6,8...10,12", nil},
+	// Error cases
+	{"1,2,", "", strconv.ErrSyntax}, // Trailing comma
+	{",1", "", strconv.ErrSyntax},   // Leading comma
+	{"1,,2", "", strconv.ErrSyntax}, // Empty item
+	{"1..2", "", strconv.ErrSyntax}, // Bad ellipsis
+}
+
+// normalizeStream adapts NormalizeStream to checkTransform.
+func normalizeStream(spec string) (string, error) {
+	var b strings.Builder
+	if err := intlist.NormalizeStream(strings.NewReader(spec), &b); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+func TestNormalizeStream(t *testing.T) {
+	checkTransform(t, "NormalizeStream", normalizeStream, normalizeStreamTests)
+}
+
+// Small chunks force merging through temporary files.
+func TestNormalizeStreamChunks(t *testing.T) {
+	defer func(n int) { *intlist.NormalizeChunk = n }(*intlist.NormalizeChunk)
+	*intlist.NormalizeChunk = 2
+	checkTransform(t, "NormalizeStream", normalizeStream, normalizeStreamTests)
+	// Many chunks in reverse order
+	items := []string{}
+	for v := 1000; v > 0; v -= 2 {
+		items = append(items, strconv.Itoa(v), strconv.Itoa(v-1))
+	}
+	out, err := normalizeStream(strings.Join(items, ","))
+	if out != "1...1000" || err != nil {
+		t.Errorf("NormalizeStream(1000...1) = (%q), (%v) -- wanted (%q), nil",
+			out, err, "1...1000")
+	}
+}
@@ -0,0 +1,50 @@
+// Copyright 2020 Brian E. Holland. All rights reserved.
+// The use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package intlist
+
+import "text/template"
+
+// TemplateFuncs returns functions for expanding and querying specifications
+// in templates, such as those generating configuration files. The functions
+// take their arguments in the same order as the functions they call, and an
+// error stops the execution of the template.
+//
+//   intlistExpand spec - Values of spec as with Parse, at most MaxJoinValues
+//   intlistJoin spec sep - ExpandJoin(spec, sep)
+//   intlistContains spec v - Whether v is a value of spec
+//
+// For example, with these functions added by template.Funcs:
+//
+//   {{range intlistExpand "1...3"}}w{{.}} {{end}} -> "w1 w2 w3 "
+//   {{intlistJoin "8000...8002" ","}} -> "8000,8001,8002"
+//   {{if intlistContains "1...10" 5}}yes{{end}} -> "yes"
+//
+// The result converts to an html/template FuncMap for use there.
+func TemplateFuncs() template.FuncMap {
+	return template.FuncMap{
+		"intlistExpand":   templateExpand,
+		"intlistJoin":     ExpandJoin,
+		"intlistContains": templateContains,
+	}
+}
+
+// templateExpand returns the values of spec for intlistExpand.
+func templateExpand(spec string) ([]int, error) {
+	c := &config{features: extensions, maxValues: MaxJoinValues}
+	seqs, err := parseSpec("intlistExpand", spec, c)
+	if err != nil {
+		return nil, err
+	}
+	return (&Iterator{seqs: seqs}).rest(), nil
+}
+
+// templateContains reports whether v is a value of spec for intlistContains.
+func templateContains(spec string, v int) (bool, error) {
+	seqs, err := parseSeqs("intlistContains", spec)
+	if err != nil {
+		return false, err
+	}
+	return containsValue(seqs, v), nil
+}
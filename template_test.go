@@ -0,0 +1,54 @@
+// Copyright 2020 Brian E. Holland. All rights reserved.
+// The use of this source code is governed by an MIT license
+// that can be found in the LICENSE file.
+
+package intlist_test
+
+import (
+	"errors"
+	htmltemplate "html/template"
+	"strconv"
+	"strings"
+	"testing"
+	"text/template"
+
+	"github.com/brianholland99/intlist"
+)
+
+var templateTests = []struct {
+	in  string
+	out string
+	err error
+}{
+	// Good cases
+	{`{{range intlistExpand "1...3"}}worker-{{.}} {{end}}`, "worker-1 worker-2 worker-3 ", nil},
+	{`{{intlistJoin "8000...8002" ","}}`, "8000,8001,8002", nil},
+	{`{{if intlistContains "1...10" 5}}yes{{end}}`, "yes", nil},
+	{`{{if intlistContains "1...10" 11}}yes{{else}}no{{end}}`, "no", nil},
+	{`{{"5...3" | intlistExpand}}`, "[5 4 3]", nil}, // Piped
+	// Error cases
+	{`{{intlistExpand "1..3"}}`, "", strconv.ErrSyntax},
+	{`{{intlistJoin "1,x" ","}}`, "", strconv.ErrSyntax},
+	{`{{intlistContains "1...3,7..9" 1}}`, "", strconv.ErrSyntax},
+	{`{{intlistExpand "1...2000000"}}`, "", intlist.ErrLimit},
+}
+
+func TestTemplateFuncs(t *testing.T) {
+	for _, test := range templateTests {
+		tmpl := template.Must(template.New("test").Funcs(intlist.TemplateFuncs()).Parse(test.in))
+		var b strings.Builder
+		err := tmpl.Execute(&b, nil)
+		if !errors.Is(err, test.err) || (err == nil && b.String() != test.out) {
+			t.Errorf("Execute(%q) = (%q), (%v) -- wanted (%q), (%v)",
+				test.in, b.String(), err, test.out, test.err)
+		}
+	}
+	// The functions also work with html/template.
+	tmpl := htmltemplate.Must(htmltemplate.New("test").Funcs(
+		htmltemplate.FuncMap(intlist.TemplateFuncs())).Parse(`{{intlistJoin "1...3" "&"}}`))
+	var b strings.Builder
+	if err := tmpl.Execute(&b, nil); err != nil || b.String() != "1&amp;2&amp;3" {
+		t.Errorf("html/template Execute = (%q), (%v) -- wanted (%q), (nil)",
+			b.String(), err, "1&amp;2&amp;3")
+	}
+}
@@ -0,0 +1,323 @@
+// Copyright 2020 Brian E. Holland. All rights reserved.
+// The use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package intlist
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// Reverse returns a specification expanding to the values of the passed
+// specification in reverse order.
+//
+// Both the order of the items and the direction of each sequence are flipped.
+//
+//   Reverse("1,5...8,20...17") -> "17...20,8...5,1", nil
+//
+// Potential errors returned:
+//
+//   strconv.ErrSyntax - Error parsing integer or sequence notation
+//   strconv.ErrRange - Integer out of range
+func Reverse(spec string) (string, error) {
+	seqs, err := parseSeqs("Reverse", spec)
+	if err != nil {
+		return "", err
+	}
+	return formatSeqs(reverseSeqs(seqs)), nil
+}
+
+// reverseSeqs returns the sequences producing the values of seqs backwards.
+func reverseSeqs(seqs []seq) []seq {
+	result := make([]seq, len(seqs))
+	for i, s := range seqs {
+		result[len(seqs)-1-i] = s.reversed()
+	}
+	return result
+}
+
+// Scale returns a specification expanding to the values of the passed
+// specification each multiplied by factor.
+//
+// The order of the values is kept. Sequences become sequences with a step of
+// factor (E.g., "4...12:4") unless factor is 1 or -1.
+//
+//   Scale("1...3,10", 4) -> "4...12:4,40", nil
+//   Scale("1...3,10", -1) -> "-1...-3,-10", nil
+//
+// Potential errors returned:
+//
+//   strconv.ErrSyntax - Error parsing integer or sequence notation
+//   strconv.ErrRange - Integer out of range, including scaled values
+//   ErrInvalidArg - factor is 0
+func Scale(spec string, factor int) (string, error) {
+	const fn = "Scale"
+	if factor == 0 {
+		return "", fmt.Errorf("intlist.Scale: factor = 0: %w", ErrInvalidArg)
+	}
+	seqs, err := parseSeqs(fn, spec)
+	if err != nil {
+		return "", err
+	}
+	result := make([]seq, 0, len(seqs))
+	for _, s := range seqs {
+		next, ok1 := mulInt(s.next, factor)
+		last, ok2 := mulInt(s.last, factor)
+		if !ok1 || !ok2 {
+			bad := s.next // Report the endpoint that overflowed.
+			if ok1 {
+				bad = s.last
+			}
+			return "", &strconv.NumError{
+				Func: fn,
+				Num:  strconv.Itoa(bad),
+				Err:  strconv.ErrRange,
+			}
+		}
+		if step, ok := mulInt(s.step, factor); ok {
+			s.next, s.last, s.step = next, last, step // Keeping any copies
+			result = append(result, s)
+		} else {
+			// The step overflows an int, so there are only the 2 endpoints.
+			result = append(result,
+				seq{next: next, last: next}, seq{next: last, last: last})
+		}
+	}
+	return formatSeqs(result), nil
+}
+
+// mulInt returns a*b and whether the product fits in an int.
+func mulInt(a, b int) (int, bool) {
+	if a == 0 || b == 0 {
+		return 0, true
+	}
+	c := a * b
+	if c/b != a || (a == -1 && b == minInt) || (b == -1 && a == minInt) {
+		return 0, false
+	}
+	return c, true
+}
+
+// Join returns a specification expanding to the values of each of the passed
+// specifications in turn.
+//
+// Each specification is parsed before joining, so exclusions only remove
+// values from their own specification. Empty specifications add no items.
+//
+//   Join("1...3", "", "7,9...8") -> "1...3,7,9...8", nil
+//   Join("1...10,!5", "5") -> "1...4,6...10,5", nil
+//
+// Potential errors returned:
+//
+//   strconv.ErrSyntax - Error parsing integer or sequence notation
+//   strconv.ErrRange - Integer out of range
+func Join(specs ...string) (string, error) {
+	var seqs []seq
+	for _, spec := range specs {
+		parsed, err := parseSeqs("Join", spec)
+		if err != nil {
+			return "", err
+		}
+		seqs = append(seqs, parsed...)
+	}
+	return formatSeqs(seqs), nil
+}
+
+// Repeat returns a specification expanding to the values of the passed
+// specification n times over, for when a finite repeated specification is
+// needed rather than an endless iteration.
+//
+//   Repeat("1...3,7", 2) -> "1...3,7,1...3,7", nil
+//   Repeat("1...3", 0) -> "", nil
+//
+// Potential errors returned:
+//
+//   strconv.ErrSyntax - Error parsing integer or sequence notation
+//   strconv.ErrRange - Integer out of range
+//   ErrInvalidArg - The "n" parameter is negative
+func Repeat(spec string, n int) (string, error) {
+	const fn = "Repeat"
+	if n < 0 {
+		return "", fmt.Errorf("intlist.%s: n = %d: %w", fn, n, ErrInvalidArg)
+	}
+	seqs, err := parseSeqs(fn, spec)
+	if err != nil {
+		return "", err
+	}
+	var result []seq
+	for k := 0; k < n; k++ {
+		result = append(result, seqs...)
+	}
+	return formatSeqs(result), nil
+}
+
+// Negate returns a specification expanding to the negation of each value of
+// the passed specification in the same order.
+//
+//   Negate("1...3,-7,10...8") -> "-1...-3,7,-10...-8", nil
+//
+// Potential errors returned:
+//
+//   strconv.ErrSyntax - Error parsing integer or sequence notation
+//   strconv.ErrRange - Integer out of range, including negated values
+func Negate(spec string) (string, error) {
+	const fn = "Negate"
+	seqs, err := parseSeqs(fn, spec)
+	if err != nil {
+		return "", err
+	}
+	result := make([]seq, len(seqs))
+	for i, s := range seqs {
+		if s.next == minInt || s.last == minInt {
+			return "", &strconv.NumError{
+				Func: fn,
+				Num:  strconv.Itoa(minInt),
+				Err:  strconv.ErrRange,
+			}
+		}
+		s.next, s.last, s.step = -s.next, -s.last, -s.step
+		result[i] = s
+	}
+	return formatSeqs(result), nil
+}
+
+// Minify returns a specification expanding to the same values in the same
+// order as the passed specification with consecutive items merged where the
+// values continue one run (E.g., "1...3,4...6,7" becomes "1...7"). Unlike a
+// normalized specification, nothing is reordered or removed.
+//
+//   Minify("1...3,4...6,7,9,8...5") -> "1...7,9...5", nil
+//
+// Potential errors returned:
+//
+//   strconv.ErrSyntax - Error parsing integer or sequence notation
+//   strconv.ErrRange - Integer out of range
+func Minify(spec string) (string, error) {
+	seqs, err := parseSeqs("Minify", spec)
+	if err != nil {
+		return "", err
+	}
+	return formatSeqs(minifySeqs(seqs)), nil
+}
+
+// Canonicalize returns the canonical specification for the set of values of
+// the passed specification, as written by NormalizeStream, so specifications
+// with the same values compare equal as strings and config diffs stay quiet.
+// The values are in increasing order with no duplicates as sequences that
+// neither overlap nor touch. Runs of values with a step keep the step
+// notation, except where their values interleave with those of another step.
+//
+//   Canonicalize("9,3...1,4,2...6") -> "1...6,9", nil
+//   Canonicalize("1,2,3") -> "1...3", nil
+//   Canonicalize("20000000...0:2,5") -> "0...4:2,5,6...20000000:2", nil
+//
+// Potential errors returned:
+//
+//   strconv.ErrSyntax - Error parsing integer or sequence notation
+//   strconv.ErrRange - Integer out of range
+//   ErrLimit - Exclusion with a step leaving too many sequences
+func Canonicalize(spec string) (string, error) {
+	seqs, err := parseSeqs("Canonicalize", spec)
+	if err != nil {
+		return "", err
+	}
+	return formatSeqs(spanSeqs(normalize(seqSpans(seqs)))), nil
+}
+
+// minifySeqs returns seqs with each sequence that continues the run of the
+// one before it merged into it. The passed slice is reused for the result.
+func minifySeqs(seqs []seq) []seq {
+	result := seqs[:0]
+	for _, s := range seqs {
+		if n := len(result); n > 0 {
+			if merged, ok := continueRun(result[n-1], s); ok {
+				result[n-1] = merged
+				continue
+			}
+		}
+		result = append(result, s)
+	}
+	return result
+}
+
+// continueRun returns the single run expanding to the values of a followed by
+// those of b, reporting false if there is none with a step of 1 or -1.
+func continueRun(a, b seq) (seq, bool) {
+	if a.reps > 0 || b.reps > 0 {
+		return a, false // Copies have no place in a run.
+	}
+	step := a.step // Direction of the run
+	if step == 0 {
+		switch {
+		case a.next != maxInt && b.next == a.next+1:
+			step = 1
+		case a.next != minInt && b.next == a.next-1:
+			step = -1
+		default:
+			return a, false
+		}
+	}
+	if (step != 1 && step != -1) || (b.step != 0 && b.step != step) {
+		return a, false
+	}
+	if (step == 1 && a.last == maxInt) || (step == -1 && a.last == minInt) ||
+		b.next != a.last+step {
+		return a, false
+	}
+	return seq{next: a.next, last: b.last, step: step}, true
+}
+
+// ConcatDedup returns a specification expanding to the values of the passed
+// specifications one after another with only the first occurrence of each
+// value kept. Unlike a set union, the order of the values is kept, so the
+// earlier specifications take priority. Items are merged as with Minify.
+//
+//   ConcatDedup("5...10", "1...7,12", "12,3") -> "5...10,1...4,12", nil
+//
+// Potential errors returned:
+//
+//   strconv.ErrSyntax - Error parsing integer or sequence notation
+//   strconv.ErrRange - Integer out of range
+func ConcatDedup(specs ...string) (string, error) {
+	var result []seq
+	var seen []span    // Values kept so far of sequences without steps, normalized
+	var strided []span // Sequences with steps kept so far, which may interleave
+	for _, spec := range specs {
+		seqs, err := parseSeqs("ConcatDedup", spec)
+		if err != nil {
+			return "", err
+		}
+		for _, s := range seqs {
+			var parts []span // Values of s not seen, in increasing order
+			for _, sp := range s.spans() {
+				parts = append(parts, uncovered(sp, seen)...)
+			}
+			for _, st := range strided {
+				var rest []span
+				for _, p := range parts {
+					rest = append(rest, uncovered(p, []span{st})...)
+				}
+				parts = rest
+			}
+			parts = joinSpans(parts)
+			if s.step < 0 {
+				for i := len(parts) - 1; i >= 0; i-- {
+					result = append(result, parts[i].seq().reversed())
+				}
+			} else {
+				for _, p := range parts {
+					result = append(result, p.seq())
+				}
+			}
+			for _, sp := range s.spans() {
+				if sp.step > 1 {
+					strided = append(strided, sp)
+				} else {
+					seen = normalize(append(seen, sp))
+				}
+			}
+		}
+	}
+	return formatSeqs(minifySeqs(result)), nil
+}
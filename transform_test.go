@@ -0,0 +1,223 @@
+// Copyright 2020 Brian E. Holland. All rights reserved.
+// The use of this source code is governed by an MIT license
+// that can be found in the LICENSE file.
+
+package intlist_test
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/brianholland99/intlist"
+)
+
+type transformTest struct {
+	in  string
+	out string
+	err error
+}
+
+// checkTransform runs the tests against a function transforming a spec.
+func checkTransform(t *testing.T, name string, fn func(string) (string, error),
+	tests []transformTest) {
+	t.Helper()
+	for _, test := range tests {
+		out, err := fn(test.in)
+		if out != test.out || !errors.Is(err, test.err) {
+			t.Errorf("%s(%q) = (%q), (%v) -- wanted (%q), (%v)",
+				name, test.in, out, err, test.out, test.err)
+		}
+	}
+}
+
+var reverseTests = []transformTest{
+	// Good cases
+	{"1,5...8,20...17", "17...20,8...5,1", nil}, // Mixed
+	{"3...3", "3", nil},                         // One-value sequence
+	{"1,7x3", "7x3,1", nil},                     // Repetition
+	{"", "", nil},                               // Empty list
+	// Error cases
+	{"1..5", "", strconv.ErrSyntax}, // Bad ellipsis
+}
+
+func TestReverse(t *testing.T) {
+	checkTransform(t, "Reverse", intlist.Reverse, reverseTests)
+}
+
+type scaleTest struct {
+	in     string
+	factor int
+	out    string
+	err    error
+}
+
+var scaleTests = []scaleTest{
+	// Good cases
+	{"1...3,10", 4, "4...12:4,40", nil},  // Strided result
+	{"1...3,10", -1, "-1...-3,-10", nil}, // Negated
+	{"5...3", 1, "5...3", nil},           // Identity
+	{"", 7, "", nil},                     // Empty list
+	// Error cases
+	{"1...4611686018427387904", 2, "", strconv.ErrRange}, // Overflow
+	{"1...3", 0, "", intlist.ErrInvalidArg},              // Zero factor
+	{"1.5", 2, "", strconv.ErrSyntax},                    // Bad spec
+}
+
+func TestScale(t *testing.T) {
+	for _, test := range scaleTests {
+		out, err := intlist.Scale(test.in, test.factor)
+		if !errors.Is(err, test.err) || (err == nil && out != test.out) {
+			t.Errorf("Scale(%q, %d) = (%q), (%v) -- wanted (%q), (%v)",
+				test.in, test.factor, out, err, test.out, test.err)
+		}
+	}
+}
+
+type joinTest struct {
+	in  []string
+	out string
+	err error
+}
+
+var joinTests = []joinTest{
+	// Good cases
+	{[]string{"1...3", "", "7,9...8"}, "1...3,7,9...8", nil}, // Skip empty
+	{[]string{}, "", nil},                               // Nothing
+	{[]string{"", ""}, "", nil},                         // All empty
+	{[]string{"1...10,!5", "5"}, "1...4,6...10,5", nil}, // Exclusion kept to its own spec
+	// Error cases
+	{[]string{"1", "2,"}, "", strconv.ErrSyntax}, // Trailing comma
+}
+
+func TestJoin(t *testing.T) {
+	for _, test := range joinTests {
+		out, err := intlist.Join(test.in...)
+		if out != test.out || !errors.Is(err, test.err) {
+			t.Errorf("Join(%q) = (%q), (%v) -- wanted (%q), (%v)",
+				test.in, out, err, test.out, test.err)
+		}
+	}
+}
+
+var repeatTests = []scaleTest{
+	// Good cases
+	{"1...3,7", 2, "1...3,7,1...3,7", nil}, // Twice
+	{"5...3", 1, "5...3", nil},             // Once
+	{"1...3", 0, "", nil},                  // No repeats
+	{"", 5, "", nil},                       // Empty list
+	// Error cases
+	{"1...3", -1, "", intlist.ErrInvalidArg},
+	{"1.5", 2, "", strconv.ErrSyntax},
+}
+
+func TestRepeat(t *testing.T) {
+	for _, test := range repeatTests {
+		out, err := intlist.Repeat(test.in, test.factor)
+		if out != test.out || !errors.Is(err, test.err) {
+			t.Errorf("Repeat(%q, %d) = (%q), (%v) -- wanted (%q), (%v)",
+				test.in, test.factor, out, err, test.out, test.err)
+		}
+	}
+}
+
+var negateTests = []transformTest{
+	// Good cases
+	{"1...3,-7,10...8", "-1...-3,7,-10...-8", nil}, // Mixed
+	{"0", "0", nil}, // Zero
+	{"", "", nil},   // Empty list
+	// Error cases
+	{"-9223372036854775808", "", strconv.ErrRange}, // No positive form
+	{"1,a", "", strconv.ErrSyntax},                 // Non-integer
+}
+
+func TestNegate(t *testing.T) {
+	if strconv.IntSize != 64 {
+		t.Skip("test cases assume 64-bit int")
+	}
+	checkTransform(t, "Negate", intlist.Negate, negateTests)
+}
+
+var minifyTests = []transformTest{
+	// Good cases
+	{"1...3,4...6,7", "1...7", nil},               // Increasing
+	{"1...3,4...6,7,9,8...5", "1...7,9...5", nil}, // Decreasing
+	{"5,4,3,3", "5...3,3", nil},                   // Repeat kept
+	{"1...3,2...5", "1...3,2...5", nil},           // Overlap kept
+	{"1...3,5", "1...3,5", nil},                   // Gap
+	{"3...1,2...4", "3...1,2...4", nil},           // Reversal
+	{"1,1", "1,1", nil},                           // Same value
+	{"", "", nil},                                 // Empty list
+	// Error cases
+	{"1..3", "", strconv.ErrSyntax},
+}
+
+func TestMinify(t *testing.T) {
+	checkTransform(t, "Minify", intlist.Minify, minifyTests)
+}
+
+var canonicalizeTests = []transformTest{
+	// Good cases
+	{"9,3...1,4,2...6", "1...6,9", nil},           // Overlap and disorder
+	{"1,2,3", "1...3", nil},                       // Consecutive values
+	{"3...1", "1...3", nil},                       // Decreasing
+	{"1,2", "1...2", nil},                         // Pair
+	{"0...6:3,1...7:3", "0...1,3...4,6...7", nil}, // Steps
+	{"0...20000000:2", "0...20000000:2", nil},
+	{"20000000...0:2,5", "0...4:2,5,6...20000000:2", nil},
+	{"0...8:4,2...10:4", "0...10:2", nil},
+	{"4...0:2,2", "0,2,4", nil},
+	{"0...2:2", "0,2", nil},
+	{"2,4,6,8...20:2,30", "2...20:2,30", nil},
+	{"8,12,14...32:2", "8,12...32:2", nil},
+	{"5,5,-5", "-5,5", nil}, // Duplicates
+	{"", "", nil},           // Empty list
+	// Error cases
+	{"1..3", "", strconv.ErrSyntax},
+}
+
+func TestCanonicalize(t *testing.T) {
+	checkTransform(t, "Canonicalize", intlist.Canonicalize, canonicalizeTests)
+	// The result is the same as NormalizeStream gives.
+	for _, test := range canonicalizeTests {
+		if test.err != nil {
+			continue
+		}
+		var b strings.Builder
+		err := intlist.NormalizeStream(strings.NewReader(test.in), &b)
+		if err != nil || b.String() != test.out {
+			t.Errorf("NormalizeStream(%q) = (%q), (%v) -- wanted (%q), (nil)",
+				test.in, b.String(), err, test.out)
+		}
+	}
+}
+
+var concatDedupTests = []struct {
+	in  []string
+	out string
+	err error
+}{
+	// Good cases
+	{[]string{"5...10", "1...7,12", "12,3"}, "5...10,1...4,12", nil}, // Priority
+	{[]string{"10...1", "3...12"}, "10...1,11...12", nil},            // Decreasing
+	{[]string{"1...10,20...30", "30...0"}, "1...10,20...30,19...11,0", nil},
+	{[]string{"1...3", "5...7", "4", "2...8"}, "1...3,5...7,4,8", nil},
+	{[]string{"0...20:5", "10...1:3", "1...3"}, "0...20:5,7...1:3,2...3", nil}, // Steps
+	{[]string{"0...1000000000:2", "0...1000000000:3"}, "0...1000000000:2,3...999999999:6", nil},
+	{[]string{"1,1,1"}, "1", nil},
+	{[]string{"", "2"}, "2", nil},
+	{nil, "", nil},
+	// Error cases
+	{[]string{"1", "1..2"}, "", strconv.ErrSyntax},
+}
+
+func TestConcatDedup(t *testing.T) {
+	for _, test := range concatDedupTests {
+		out, err := intlist.ConcatDedup(test.in...)
+		if out != test.out || !errors.Is(err, test.err) {
+			t.Errorf("ConcatDedup(%q) = (%q), (%v) -- wanted (%q), (%v)",
+				test.in, out, err, test.out, test.err)
+		}
+	}
+}
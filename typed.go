@@ -0,0 +1,151 @@
+// Copyright 2020 Brian E. Holland. All rights reserved.
+// The use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package intlist
+
+import "strconv"
+
+// Integer is a constraint permitting any integer type.
+type Integer interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr
+}
+
+// IteratorOf is the state for generating integers of type T from an intlist
+// description.
+type IteratorOf[T Integer] struct {
+	it *Iterator // Source of values
+}
+
+// NewIteratorAs is NewIteratorWithOptions for values of type T, so consumers
+// of types other than int need not convert each value. Every value must fit
+// in T unless WithSaturation is passed in "opts".
+//
+//   NewIteratorAs[uint8]("250...255") -> [250 251 252 253 254 255]
+//   NewIteratorAs[uint8]("250...256") -> strconv.ErrRange
+//
+// Potential errors set in state during creation of an IteratorOf:
+//
+//   strconv.ErrSyntax - Error parsing integer or sequence notation
+//   strconv.ErrRange - Integer out of range of int or T
+//   Errors of NewIteratorWithOptions for the options passed
+func NewIteratorAs[T Integer](spec string, opts ...Option) *IteratorOf[T] {
+	return &IteratorOf[T]{it: newIterator("NewIteratorAs", spec, typedConfig[T](newConfig(opts)))}
+}
+
+// typedConfig returns c changed to require values that fit in T.
+func typedConfig[T Integer](c *config) *config {
+	c.typed = true
+	c.typeLo, c.typeHi = typeRange[T]()
+	return c
+}
+
+// typeRange returns the range of values of T that are also ints.
+func typeRange[T Integer]() (lo, hi int) {
+	bits := 0
+	for x := T(1); x != 0; x <<= 1 {
+		bits++
+	}
+	var zero T
+	if ^zero > 0 { // Unsigned
+		if bits >= strconv.IntSize {
+			return 0, maxInt
+		}
+		return 0, 1<<bits - 1
+	}
+	if bits >= strconv.IntSize {
+		return minInt, maxInt
+	}
+	return -1 << (bits - 1), 1<<(bits-1) - 1
+}
+
+// Next returns the next integer if not done and an error to indicate if done.
+// It is Iterator.Next for values of type T and panics in the same cases.
+func (i *IteratorOf[T]) Next() (T, error) {
+	val, err := i.it.Next()
+	return T(val), err
+}
+
+// Err returns any error that occured when creating this IteratorOf.
+func (i *IteratorOf[T]) Err() error {
+	return i.it.Err()
+}
+
+// Done reports whether a previous Next call returned ErrDone to indicate that
+// the end of the iteration occurred.
+func (i *IteratorOf[T]) Done() bool {
+	return i.it.Done()
+}
+
+// ParseOf is Parse for values of type T, so a specification can be expanded
+// directly into a slice of the type a consumer uses. Every value must fit in
+// T. See NewIteratorAs for iterating instead.
+//
+//   ParseOf[uint16]("65534...65535") -> [65534 65535], nil
+//   ParseOf[uint16]("-1...1") -> nil, strconv.ErrRange
+//
+// Potential errors returned:
+//
+//   strconv.ErrSyntax - Error parsing integer or sequence notation
+//   strconv.ErrRange - Integer out of range of int or T
+func ParseOf[T Integer](spec string) ([]T, error) {
+	return parseAs[T]("ParseOf", spec)
+}
+
+// ParseInt8 is Parse for values that must fit in an int8.
+//
+//   ParseInt8("-2...1,100") -> [-2 -1 0 1 100], nil
+//   ParseInt8("100...200") -> nil, strconv.ErrRange
+//
+// Potential errors returned:
+//
+//   strconv.ErrSyntax - Error parsing integer or sequence notation
+//   strconv.ErrRange - Integer out of range of an int8
+func ParseInt8(spec string) ([]int8, error) {
+	return parseAs[int8]("ParseInt8", spec)
+}
+
+// ParseInt16 is Parse for values that must fit in an int16.
+//
+//   ParseInt16("1000...1002") -> [1000 1001 1002], nil
+//
+// Potential errors returned:
+//
+//   strconv.ErrSyntax - Error parsing integer or sequence notation
+//   strconv.ErrRange - Integer out of range of an int16
+func ParseInt16(spec string) ([]int16, error) {
+	return parseAs[int16]("ParseInt16", spec)
+}
+
+// ParseInt32 is Parse for values that must fit in an int32.
+//
+//   ParseInt32("70000,-70000") -> [70000 -70000], nil
+//
+// Potential errors returned:
+//
+//   strconv.ErrSyntax - Error parsing integer or sequence notation
+//   strconv.ErrRange - Integer out of range of an int32
+func ParseInt32(spec string) ([]int32, error) {
+	return parseAs[int32]("ParseInt32", spec)
+}
+
+// parseAs returns the values of spec as type T, failing unless they all fit.
+// The "fn" parameter is the name of the calling function used in any error
+// returned.
+func parseAs[T Integer](fn, spec string) ([]T, error) {
+	seqs, err := parseSpec(fn, spec, typedConfig[T](&config{}))
+	if err != nil {
+		return nil, err
+	}
+	it := &Iterator{seqs: seqs}
+	result := []T{}
+	for {
+		val, err := it.Next()
+		if err == ErrDone {
+			break
+		}
+		result = append(result, T(val))
+	}
+	return result, nil
+}
@@ -0,0 +1,155 @@
+// Copyright 2020 Brian E. Holland. All rights reserved.
+// The use of this source code is governed by an MIT license
+// that can be found in the LICENSE file.
+
+package intlist_test
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/brianholland99/intlist"
+	"github.com/google/go-cmp/cmp"
+)
+
+type typedTest[T intlist.Integer] struct {
+	in  string
+	out []T
+	err error
+}
+
+// checkIteratorAs runs the tests of NewIteratorAs for T.
+func checkIteratorAs[T intlist.Integer](t *testing.T, tests []typedTest[T]) {
+	t.Helper()
+	for _, test := range tests {
+		it := intlist.NewIteratorAs[T](test.in)
+		if !errors.Is(it.Err(), test.err) {
+			t.Errorf("NewIteratorAs[%T](%q) error = (%v) -- wanted (%v)",
+				T(0), test.in, it.Err(), test.err)
+			continue
+		}
+		if test.err != nil {
+			continue
+		}
+		out := []T{}
+		for {
+			val, err := it.Next()
+			if err == intlist.ErrDone {
+				break
+			}
+			out = append(out, val)
+		}
+		if !cmp.Equal(out, test.out) || !it.Done() {
+			t.Errorf("NewIteratorAs[%T](%q) = %v -- wanted %v", T(0), test.in, out, test.out)
+		}
+	}
+}
+
+// checkParseOf runs the tests of ParseOf for T.
+func checkParseOf[T intlist.Integer](t *testing.T, tests []typedTest[T]) {
+	t.Helper()
+	for _, test := range tests {
+		out, err := intlist.ParseOf[T](test.in)
+		if !cmp.Equal(out, test.out) || !errors.Is(err, test.err) {
+			t.Errorf("ParseOf[%T](%q) = (%v), (%v) -- wanted (%v), (%v)",
+				T(0), test.in, out, err, test.out, test.err)
+		}
+	}
+}
+
+type myInt16 int16 // Named types are accepted too.
+
+var uint8Tests = []typedTest[uint8]{
+	{"250...255", []uint8{250, 251, 252, 253, 254, 255}, nil},
+	{"0,3...1", []uint8{0, 3, 2, 1}, nil},
+	{"", []uint8{}, nil},
+	{"250...256", nil, strconv.ErrRange},
+	{"-1", nil, strconv.ErrRange},
+	{"1..2", nil, strconv.ErrSyntax},
+}
+
+var myInt16Tests = []typedTest[myInt16]{
+	{"-32768,32767", []myInt16{-32768, 32767}, nil},
+	{"32768", nil, strconv.ErrRange},
+	{"-32769...0", nil, strconv.ErrRange},
+}
+
+var uint64Tests = []typedTest[uint64]{
+	{"1...3", []uint64{1, 2, 3}, nil},
+	{"-1", nil, strconv.ErrRange},
+}
+
+var int64Tests = []typedTest[int64]{
+	{"-2...2", []int64{-2, -1, 0, 1, 2}, nil},
+}
+
+func TestIteratorAs(t *testing.T) {
+	checkIteratorAs(t, uint8Tests)
+	checkIteratorAs(t, myInt16Tests)
+	checkIteratorAs(t, uint64Tests)
+	checkIteratorAs(t, int64Tests)
+}
+
+// Options setting how an Iterator behaves apply to an IteratorOf too.
+func TestIteratorAsOptions(t *testing.T) {
+	it := intlist.NewIteratorAs[uint8]("256", intlist.Untrusted())
+	if _, err := it.Next(); !errors.Is(err, strconv.ErrRange) {
+		t.Errorf("NewIteratorAs[uint8](%q, Untrusted()).Next() error = %v -- wanted %v", "256",
+			err, strconv.ErrRange)
+	}
+	var progress []int
+	it = intlist.NewIteratorAs[uint8]("1...5", intlist.WithProgress(2, func(done, total int) {
+		progress = append(progress, done)
+	}))
+	for _, err := it.Next(); err == nil; _, err = it.Next() {
+	}
+	if want := []int{2, 4}; !cmp.Equal(progress, want) {
+		t.Errorf("NewIteratorAs[uint8](%q, WithProgress(2, f)) reported %v -- wanted %v", "1...5",
+			progress, want)
+	}
+}
+
+func TestParseOf(t *testing.T) {
+	checkParseOf(t, uint8Tests)
+	checkParseOf(t, myInt16Tests)
+	checkParseOf(t, uint64Tests)
+	checkParseOf(t, int64Tests)
+	checkParseOf(t, []typedTest[uint16]{
+		{"65534...65535", []uint16{65534, 65535}, nil},
+		{"-1...1", nil, strconv.ErrRange},
+	})
+}
+
+func TestParseSized(t *testing.T) {
+	tests := []struct {
+		name string
+		fn   func(string) (interface{}, error)
+		in   string
+		out  interface{}
+		err  error
+	}{
+		{"ParseInt8", parseInt8, "-2...1,100", []int8{-2, -1, 0, 1, 100}, nil},
+		{"ParseInt8", parseInt8, "-128,127", []int8{-128, 127}, nil},
+		{"ParseInt8", parseInt8, "100...200", []int8(nil), strconv.ErrRange},
+		{"ParseInt8", parseInt8, "-129", []int8(nil), strconv.ErrRange},
+		{"ParseInt16", parseInt16, "1000...1002", []int16{1000, 1001, 1002}, nil},
+		{"ParseInt16", parseInt16, "", []int16{}, nil},
+		{"ParseInt16", parseInt16, "40000", []int16(nil), strconv.ErrRange},
+		{"ParseInt32", parseInt32, "70000,-70000", []int32{70000, -70000}, nil},
+		{"ParseInt32", parseInt32, "2147483648", []int32(nil), strconv.ErrRange},
+		{"ParseInt32", parseInt32, "1..2", []int32(nil), strconv.ErrSyntax},
+	}
+	for _, test := range tests {
+		out, err := test.fn(test.in)
+		if !cmp.Equal(out, test.out) || !errors.Is(err, test.err) {
+			t.Errorf("%s(%q) = (%v), (%v) -- wanted (%v), (%v)",
+				test.name, test.in, out, err, test.out, test.err)
+		}
+	}
+}
+
+// Adapters giving the sized parsers a common type for the test table
+func parseInt8(spec string) (interface{}, error)  { return intlist.ParseInt8(spec) }
+func parseInt16(spec string) (interface{}, error) { return intlist.ParseInt16(spec) }
+func parseInt32(spec string) (interface{}, error) { return intlist.ParseInt32(spec) }
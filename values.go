@@ -0,0 +1,48 @@
+// Copyright 2020 Brian E. Holland. All rights reserved.
+// The use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+//go:build go1.23
+
+package intlist
+
+import "iter"
+
+// Values returns the remaining values of the iteration for use in a range
+// loop. An invalid Iterator yields nothing, so check Err first. Stopping the
+// loop early leaves the rest of the values for a later Next or Values call.
+//
+//   it := intlist.NewIterator("1...3,10")
+//   for v := range it.Values() {
+//       fmt.Println(v) // 1, 2, 3 and 10
+//   }
+func (i *Iterator) Values() iter.Seq[int] {
+	return func(yield func(int) bool) {
+		if i.err != nil {
+			return
+		}
+		for !i.done {
+			v, err := i.Next()
+			if err != nil || !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Values validates the specification and returns its values for use in a
+// range loop.
+//
+//   Values("1...3,10") -> [1 2 3 10], nil
+//
+// Potential errors returned:
+//
+//   strconv.ErrSyntax - Error parsing integer or sequence notation
+//   strconv.ErrRange - Integer out of range
+func Values(spec string) (iter.Seq[int], error) {
+	it := NewIterator(spec)
+	if it.Err() != nil {
+		return nil, it.Err()
+	}
+	return it.Values(), nil
+}
@@ -0,0 +1,57 @@
+// Copyright 2020 Brian E. Holland. All rights reserved.
+// The use of this source code is governed by an MIT license
+// that can be found in the LICENSE file.
+
+//go:build go1.23
+
+package intlist_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/brianholland99/intlist"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestValues(t *testing.T) {
+	for _, test := range parseTests {
+		values, err := intlist.Values(test.in)
+		if !errors.Is(err, test.err) {
+			t.Errorf("Values(%q) error = (%v) -- wanted (%v)", test.in, err, test.err)
+			continue
+		}
+		if test.err != nil {
+			continue
+		}
+		out := []int{}
+		for v := range values {
+			out = append(out, v)
+		}
+		if !cmp.Equal(out, test.out) {
+			t.Errorf("Values(%q) = %v -- wanted %v", test.in, out, test.out)
+		}
+	}
+}
+
+func TestIteratorValues(t *testing.T) {
+	it := intlist.NewIterator("1...5")
+	var first []int
+	for v := range it.Values() {
+		if v == 3 {
+			break // The rest are left for later.
+		}
+		first = append(first, v)
+	}
+	rest := collect(it)
+	if !cmp.Equal(first, []int{1, 2}) || !cmp.Equal(rest, []int{4, 5}) {
+		t.Errorf("Values() stopped at 3 = %v then %v -- wanted [1 2] then [4 5]",
+			first, rest)
+	}
+	for v := range it.Values() {
+		t.Errorf("Values() after ErrDone yielded %d -- wanted nothing", v)
+	}
+	for v := range intlist.NewIterator("1..5").Values() {
+		t.Errorf("Values() of invalid Iterator yielded %d -- wanted nothing", v)
+	}
+}
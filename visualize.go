@@ -0,0 +1,130 @@
+// Copyright 2020 Brian E. Holland. All rights reserved.
+// The use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package intlist
+
+import (
+	"fmt"
+	"math/bits"
+	"sort"
+	"strings"
+)
+
+// Cells of a Visualize bar
+const (
+	cellEmpty   = '·' // No values
+	cellPartial = '░' // Some values
+	cellFull    = '█' // Every value
+	cellOverlap = '▓' // A value from more than one item
+)
+
+// Visualize returns a bar of width cells showing which integers from lo
+// through hi are values of the passed specification, so gaps and overlaps
+// stand out when reviewing allocations in a terminal. Each cell stands for
+// an equal share of the interval and is "█" if all of its integers are
+// values, "░" if some are, "·" if none are, and "▓" if any value comes from
+// more than one item. The bar is narrower if there are fewer than width
+// integers from lo through hi.
+//
+//   Visualize("1...2,5...6,6,10", 1, 10, 10) -> "██··█▓···█", nil
+//   Visualize("1...50", 1, 100, 4) -> "██··", nil
+//
+// Potential errors returned:
+//
+//   strconv.ErrSyntax - Error parsing integer or sequence notation
+//   strconv.ErrRange - Integer out of range
+//   ErrInvalidArg - lo is greater than hi or width is not positive
+func Visualize(spec string, lo, hi, width int) (string, error) {
+	const fn = "Visualize"
+	if lo > hi || width <= 0 {
+		return "", fmt.Errorf("intlist.%s: interval [%d, %d], width %d: %w",
+			fn, lo, hi, width, ErrInvalidArg)
+	}
+	seqs, err := parseSeqs(fn, spec)
+	if err != nil {
+		return "", err
+	}
+	spans := seqSpans(seqs)
+	sort.Slice(spans, func(i, j int) bool { return spans[i].lo < spans[j].lo })
+	overlaps := overlapSpans(spans)
+	covered := normalize(spans)
+
+	// There are total integers in the interval, or 2^64 if total is 0.
+	total := uint64(hi) - uint64(lo) + 1
+	if total != 0 && uint64(width) > total {
+		width = int(total)
+	}
+	var b strings.Builder
+	for k := 0; k < width; k++ {
+		cell := span{lo: cellStart(lo, total, width, k), hi: hi}
+		if k < width-1 {
+			cell.hi = cellStart(lo, total, width, k+1) - 1
+		}
+		var have uint64 // Values in the cell
+		for _, sp := range covered {
+			have += overlapLen(sp, cell)
+		}
+		switch {
+		case anyOverlap(overlaps, cell):
+			b.WriteRune(cellOverlap)
+		case have == 0:
+			b.WriteRune(cellEmpty)
+		case have == uint64(cell.hi)-uint64(cell.lo)+1:
+			b.WriteRune(cellFull)
+		default:
+			b.WriteRune(cellPartial)
+		}
+	}
+	return b.String(), nil
+}
+
+// cellStart returns the first integer of cell k of width cells sharing the
+// total integers starting at lo, where a total of 0 means 2^64.
+func cellStart(lo int, total uint64, width, k int) int {
+	var offset uint64
+	if total == 0 {
+		offset, _ = bits.Div64(uint64(k), 0, uint64(width))
+	} else {
+		h, l := bits.Mul64(total, uint64(k))
+		offset, _ = bits.Div64(h, l, uint64(width))
+	}
+	return int(uint64(lo) + offset)
+}
+
+// overlapSpans returns the spans of values covered more than once by spans,
+// which must be sorted by their start.
+func overlapSpans(spans []span) []span {
+	var result []span
+	var active []span // Spans before sp that reach it
+	for _, sp := range spans {
+		reaching := active[:0]
+		for _, a := range active {
+			if a.hi >= sp.lo {
+				reaching = append(reaching, a)
+				result = append(result, a.intersect(sp)...)
+			}
+		}
+		active = append(reaching, sp)
+	}
+	return result
+}
+
+// overlapLen returns the number of values of sp in cell, whose values are
+// consecutive.
+func overlapLen(sp, cell span) uint64 {
+	if sp, ok := sp.clip(cell.lo, cell.hi); ok {
+		return sp.len()
+	}
+	return 0
+}
+
+// anyOverlap reports whether any of spans shares an integer with cell.
+func anyOverlap(spans []span, cell span) bool {
+	for _, sp := range spans {
+		if _, ok := sp.clip(cell.lo, cell.hi); ok {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,46 @@
+// Copyright 2020 Brian E. Holland. All rights reserved.
+// The use of this source code is governed by an MIT license
+// that can be found in the LICENSE file.
+
+package intlist_test
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/brianholland99/intlist"
+)
+
+var visualizeTests = []struct {
+	in            string
+	lo, hi, width int
+	out           string
+	err           error
+}{
+	// Good cases
+	{"1...2,5...6,6,10", 1, 10, 10, "██··█▓···█", nil}, // Overlap
+	{"1...50", 1, 100, 4, "██··", nil},                 // Cells of 25
+	{"1...3", 1, 8, 4, "█░··", nil},                    // Partial cell
+	{"8...1", 1, 8, 2, "██", nil},                      // Decreasing
+	{"1...5,3...9", 1, 10, 5, "█▓▓█░", nil},            // Overlapping items
+	{"3", 1, 5, 20, "··█··", nil},                      // Narrower than width
+	{"1...9:2,3", 1, 10, 10, "█·▓·█·█·█·", nil},        // Steps
+	{"0...2000000000:2", 0, 1999999999, 4, "░░░░", nil},
+	{"0...10:2,1...11:2", 0, 11, 4, "████", nil},
+	{"", 1, 5, 5, "·····", nil}, // Empty list
+	// Error cases
+	{"1...5", 5, 4, 3, "", intlist.ErrInvalidArg},
+	{"1...5", 1, 5, 0, "", intlist.ErrInvalidArg},
+	{"1..5", 1, 5, 5, "", strconv.ErrSyntax},
+}
+
+func TestVisualize(t *testing.T) {
+	for _, test := range visualizeTests {
+		out, err := intlist.Visualize(test.in, test.lo, test.hi, test.width)
+		if out != test.out || !errors.Is(err, test.err) {
+			t.Errorf("Visualize(%q, %d, %d, %d) = (%q), (%v) -- wanted (%q), (%v)",
+				test.in, test.lo, test.hi, test.width, out, err, test.out, test.err)
+		}
+	}
+}
@@ -0,0 +1,203 @@
+// Copyright 2020 Brian E. Holland. All rights reserved.
+// The use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package intlist
+
+import (
+	"strconv"
+	"strings"
+)
+
+// wideInt is the types of the 64-bit parsers, which accept values an int
+// can't hold on a 32-bit platform.
+type wideInt interface {
+	int64 | uint64
+}
+
+// wideSeq is a seq of 64-bit values.
+type wideSeq[T wideInt] struct {
+	next, last T      // Next and last values of the sequence
+	stride     uint64 // Distance between values, 0 for a single value
+	down       bool   // Whether the sequence is decreasing
+}
+
+// Iterator64 is the state for generating int64 values from an intlist
+// description.
+type Iterator64 struct {
+	seqs []wideSeq[int64] // Remaining sequences to handle
+	err  error            // Error in creating, if any
+	done bool             // Next has returned ErrDone
+}
+
+// NewIterator64 is NewIterator for int64 values, so values beyond an int on
+// 32-bit platforms, such as database keys, can be used.
+//
+//   NewIterator64("4294967296...4294967298") ->
+//       [4294967296 4294967297 4294967298]
+//
+// Potential errors set in state during creation of an Iterator64:
+//
+//   strconv.ErrSyntax - Error parsing integer or sequence notation
+//   strconv.ErrRange - Integer out of range of an int64
+//   ErrFeature - Syntax extension used
+func NewIterator64(spec string) *Iterator64 {
+	seqs, err := parseWide("NewIterator64", spec, func(tok string) (int64, error) {
+		return strconv.ParseInt(tok, 10, 64)
+	})
+	return &Iterator64{seqs: seqs, err: err}
+}
+
+// Next returns the next integer if not done and an error to indicate if done.
+// It is Iterator.Next for int64 values and panics in the same cases.
+func (i *Iterator64) Next() (int64, error) {
+	if i.err != nil {
+		panic("Next() called on invalid iterator.")
+	}
+	if i.done {
+		panic("Next() called again after returning ErrDone.")
+	}
+	val, ok := nextWide(&i.seqs)
+	if !ok {
+		i.done = true
+		return 0, ErrDone
+	}
+	return val, nil
+}
+
+// Err returns any error that occured when creating this Iterator64.
+func (i *Iterator64) Err() error {
+	return i.err
+}
+
+// Done reports whether a previous Next call returned ErrDone to indicate that
+// the end of the iteration occurred.
+func (i *Iterator64) Done() bool {
+	return i.done
+}
+
+// Parse64 is Parse for int64 values.
+//
+//   Parse64("-5000000000,1...3") -> [-5000000000 1 2 3], nil
+//
+// Potential errors returned:
+//
+//   strconv.ErrSyntax - Error parsing integer or sequence notation
+//   strconv.ErrRange - Integer out of range of an int64
+//   ErrFeature - Syntax extension used
+func Parse64(spec string) ([]int64, error) {
+	it := NewIterator64(spec)
+	if it.Err() != nil {
+		return nil, it.Err()
+	}
+	result := []int64{}
+	for {
+		val, err := it.Next()
+		if err == ErrDone {
+			return result, nil
+		}
+		result = append(result, val)
+	}
+}
+
+// ParseUint64 is Parse for uint64 values, so unsigned IDs above the largest
+// int64 can be used. Negative values are syntax errors.
+//
+//   ParseUint64("18446744073709551614...18446744073709551615") ->
+//       [18446744073709551614 18446744073709551615], nil
+//
+// Potential errors returned:
+//
+//   strconv.ErrSyntax - Error parsing integer or sequence notation
+//   strconv.ErrRange - Integer out of range of a uint64
+//   ErrFeature - Syntax extension used
+func ParseUint64(spec string) ([]uint64, error) {
+	seqs, err := parseWide("ParseUint64", spec, func(tok string) (uint64, error) {
+		return strconv.ParseUint(tok, 10, 64)
+	})
+	if err != nil {
+		return nil, err
+	}
+	result := []uint64{}
+	for {
+		val, ok := nextWide(&seqs)
+		if !ok {
+			return result, nil
+		}
+		result = append(result, val)
+	}
+}
+
+// parseWide parses spec into its sequences of T using parse for each value.
+// Only the base syntax is accepted, as with Parse, so syntax extensions such as
+// steps and repeats are rejected with ErrFeature. The "fn" parameter is the name of the
+// calling function used in any error returned.
+func parseWide[T wideInt](fn, spec string, parse func(string) (T, error)) ([]wideSeq[T], error) {
+	c := &config{}
+	items, offs := c.split(spec)
+	if len(items) == 1 && items[0] == "" {
+		return []wideSeq[T]{}, nil
+	}
+	seqs := make([]wideSeq[T], 0, len(items))
+	for i, item := range items {
+		s, err := parseWideItem(c, fn, item, parse)
+		if err != nil {
+			return nil, c.annotate(err, spec, item, offs[i])
+		}
+		seqs = append(seqs, s)
+	}
+	return seqs, nil
+}
+
+// parseWideItem parses a single item of a specification for parseWide using
+// the configuration c.
+func parseWideItem[T wideInt](c *config, fn, item string, parse func(string) (T, error)) (wideSeq[T], error) {
+	var s wideSeq[T]
+	var err error
+	parts := strings.Split(item, "...")
+	switch len(parts) {
+	case 1: // Single value
+		if _, _, ok := c.repetition(parts[0]); ok {
+			return s, featureError(fn, item) // Repeats are an extension.
+		}
+		s.next, err = parse(parts[0])
+		s.last = s.next
+		return s, err
+	case 2: // Sequence
+	default:
+		return s, &strconv.NumError{Func: fn, Num: item, Err: strconv.ErrSyntax}
+	}
+	if strings.Contains(parts[1], ":") {
+		return s, featureError(fn, item) // Steps are an extension.
+	}
+	if s.next, err = parse(parts[0]); err != nil {
+		return s, err
+	}
+	if s.last, err = parse(parts[1]); err != nil {
+		return s, err
+	}
+	if s.next != s.last {
+		s.down = s.last < s.next
+		s.stride = 1
+	}
+	return s, nil
+}
+
+// nextWide removes and returns the first value of seqs, reporting false if
+// there are none.
+func nextWide[T wideInt](seqs *[]wideSeq[T]) (T, bool) {
+	if len(*seqs) == 0 {
+		return 0, false
+	}
+	s := &(*seqs)[0]
+	val := s.next
+	switch {
+	case val == s.last:
+		*seqs = (*seqs)[1:]
+	case s.down:
+		s.next = T(uint64(s.next) - s.stride)
+	default:
+		s.next = T(uint64(s.next) + s.stride)
+	}
+	return val, true
+}
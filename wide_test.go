@@ -0,0 +1,85 @@
+// Copyright 2020 Brian E. Holland. All rights reserved.
+// The use of this source code is governed by an MIT license
+// that can be found in the LICENSE file.
+
+package intlist_test
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/brianholland99/intlist"
+	"github.com/google/go-cmp/cmp"
+)
+
+var parse64Tests = []typedTest[int64]{
+	// Good cases
+	{"-5000000000,1...3", []int64{-5000000000, 1, 2, 3}, nil},
+	{"4294967296...4294967298", []int64{4294967296, 4294967297, 4294967298}, nil},
+	{"9223372036854775806...9223372036854775807",
+		[]int64{9223372036854775806, 9223372036854775807}, nil}, // Largest
+	{"-9223372036854775807...-9223372036854775808",
+		[]int64{-9223372036854775807, -9223372036854775808}, nil}, // Smallest
+	{"", []int64{}, nil},
+	// Error cases
+	{"9223372036854775808", nil, strconv.ErrRange},
+	{"1..3", nil, strconv.ErrSyntax},
+	{"1...2...3", nil, strconv.ErrSyntax},
+	{"10...0:4", nil, intlist.ErrFeature}, // Step, as with Parse
+	{"7x3", nil, intlist.ErrFeature},      // Repeat, as with Parse
+}
+
+func TestParse64(t *testing.T) {
+	for _, test := range parse64Tests {
+		out, err := intlist.Parse64(test.in)
+		if !cmp.Equal(out, test.out) || !errors.Is(err, test.err) {
+			t.Errorf("Parse64(%q) = (%v), (%v) -- wanted (%v), (%v)",
+				test.in, out, err, test.out, test.err)
+		}
+	}
+}
+
+func TestIterator64(t *testing.T) {
+	for _, test := range parse64Tests {
+		it := intlist.NewIterator64(test.in)
+		if !errors.Is(it.Err(), test.err) {
+			t.Errorf("NewIterator64(%q) error = (%v) -- wanted (%v)", test.in, it.Err(), test.err)
+			continue
+		}
+		if test.err != nil {
+			continue
+		}
+		out := []int64{}
+		for {
+			val, err := it.Next()
+			if err == intlist.ErrDone {
+				break
+			}
+			out = append(out, val)
+		}
+		if !cmp.Equal(out, test.out) || !it.Done() {
+			t.Errorf("NewIterator64(%q) = %v, Done() = %v -- wanted %v, true",
+				test.in, out, it.Done(), test.out)
+		}
+	}
+}
+
+func TestParseUint64(t *testing.T) {
+	for _, test := range []typedTest[uint64]{
+		// Good cases
+		{"18446744073709551614...18446744073709551615",
+			[]uint64{18446744073709551614, 18446744073709551615}, nil}, // Largest
+		{"3...0", []uint64{3, 2, 1, 0}, nil},
+		{"", []uint64{}, nil},
+		// Error cases
+		{"18446744073709551616", nil, strconv.ErrRange},
+		{"-1...1", nil, strconv.ErrSyntax}, // Negative
+	} {
+		out, err := intlist.ParseUint64(test.in)
+		if !cmp.Equal(out, test.out) || !errors.Is(err, test.err) {
+			t.Errorf("ParseUint64(%q) = (%v), (%v) -- wanted (%v), (%v)",
+				test.in, out, err, test.out, test.err)
+		}
+	}
+}
@@ -0,0 +1,99 @@
+// Copyright 2020 Brian E. Holland. All rights reserved.
+// The use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package intlist
+
+import "fmt"
+
+// WindowIterator is the state for generating fixed-size windows of the values
+// from an intlist description.
+type WindowIterator struct {
+	src     *Iterator // Source of values
+	size    int       // Number of values in a window
+	advance int       // Number of values between the starts of windows
+	window  []int     // Previous window returned, if any
+	err     error     // Error in creating, if any
+	done    bool      // Next has returned ErrDone
+}
+
+// NewWindowIterator validates the specification and sets the state for
+// iterating through successive windows of size values of its expansion, with
+// the start of each window advance values after the start of the previous
+// one. Windows overlap if advance is less than size and skip values if it is
+// greater. Values left over that do not fill a window are not returned.
+//
+//   NewWindowIterator("1...6", 3, 2) -> [[1 2 3] [3 4 5]]
+//
+// Potential errors set in state during creation of a WindowIterator:
+//
+//   strconv.ErrSyntax - Error parsing integer or sequence notation
+//   strconv.ErrRange - Integer out of range
+//   ErrInvalidArg - The "size" or "advance" parameter is not positive
+func NewWindowIterator(spec string, size, advance int) *WindowIterator {
+	const fn = "NewWindowIterator"
+	if size <= 0 || advance <= 0 {
+		return &WindowIterator{err: fmt.Errorf(
+			"intlist.%s: size = %d, advance = %d: %w", fn, size, advance, ErrInvalidArg)}
+	}
+	seqs, err := parseSeqs(fn, spec)
+	if err != nil {
+		return &WindowIterator{err: err}
+	}
+	return &WindowIterator{
+		src:     &Iterator{seqs: seqs},
+		size:    size,
+		advance: advance,
+	}
+}
+
+// Next returns the next window if not done and an error to indicate if done.
+// The returned slice is not reused by later calls.
+//
+// If ErrDone is returned the window is nil and there are no more windows.
+//
+// It will panic for the following avoidable cases:
+//   - Next called on invalid iterator.
+//   - Next called after previous call to Next() returned ErrDone.
+func (w *WindowIterator) Next() ([]int, error) {
+	if w.err != nil {
+		panic("Next() called on invalid iterator.")
+	}
+	if w.done {
+		// Caller was already informed that iterator was done.
+		panic("Next() called again after returning ErrDone.")
+	}
+	window := make([]int, 0, w.size)
+	skip := 0 // Values to drop before the window
+	if w.window != nil {
+		if w.advance < w.size {
+			window = append(window, w.window[w.advance:]...)
+		} else {
+			skip = w.advance - w.size
+		}
+	}
+	for len(window) < w.size {
+		val, err := w.src.Next()
+		if err == ErrDone {
+			w.done = true
+			return nil, ErrDone
+		}
+		if skip > 0 {
+			skip--
+			continue
+		}
+		window = append(window, val)
+	}
+	w.window = window
+	return window, nil
+}
+
+// Err returns any error that occured when creating this WindowIterator.
+func (w *WindowIterator) Err() error {
+	return w.err
+}
+
+// Done reports whether a previous Next call returned ErrDone.
+func (w *WindowIterator) Done() bool {
+	return w.done
+}
@@ -0,0 +1,59 @@
+// Copyright 2020 Brian E. Holland. All rights reserved.
+// The use of this source code is governed by an MIT license
+// that can be found in the LICENSE file.
+
+package intlist_test
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/brianholland99/intlist"
+	"github.com/google/go-cmp/cmp"
+)
+
+var windowTests = []struct {
+	in            string
+	size, advance int
+	out           [][]int
+	err           error
+}{
+	// Good cases
+	{"1...6", 3, 2, [][]int{{1, 2, 3}, {3, 4, 5}}, nil},   // Overlapping
+	{"1...6", 2, 2, [][]int{{1, 2}, {3, 4}, {5, 6}}, nil}, // Touching
+	{"1...8", 2, 3, [][]int{{1, 2}, {4, 5}, {7, 8}}, nil}, // Skipping
+	{"9...7,1", 1, 1, [][]int{{9}, {8}, {7}, {1}}, nil},   // Single values
+	{"1,2", 3, 1, [][]int{}, nil},                         // Too few values
+	{"", 1, 1, [][]int{}, nil},                            // Empty list
+	// Error cases
+	{"1...6", 0, 1, nil, intlist.ErrInvalidArg},
+	{"1...6", 2, -1, nil, intlist.ErrInvalidArg},
+	{"1..6", 2, 1, nil, strconv.ErrSyntax},
+}
+
+func TestWindowIterator(t *testing.T) {
+	for _, test := range windowTests {
+		it := intlist.NewWindowIterator(test.in, test.size, test.advance)
+		if !errors.Is(it.Err(), test.err) {
+			t.Errorf("NewWindowIterator(%q, %d, %d) error = (%v) -- wanted (%v)",
+				test.in, test.size, test.advance, it.Err(), test.err)
+			continue
+		}
+		if test.err != nil {
+			continue
+		}
+		out := [][]int{}
+		for {
+			window, err := it.Next()
+			if err == intlist.ErrDone {
+				break
+			}
+			out = append(out, window)
+		}
+		if !cmp.Equal(out, test.out) || !it.Done() {
+			t.Errorf("NewWindowIterator(%q, %d, %d) = %v, done %v -- wanted %v, done true",
+				test.in, test.size, test.advance, out, it.Done(), test.out)
+		}
+	}
+}